@@ -0,0 +1,164 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/boz/circumspect/resolver/containerd"
+	"github.com/boz/circumspect/resolver/cri"
+	"github.com/boz/circumspect/resolver/docker"
+	"github.com/boz/circumspect/resolver/kube"
+	"github.com/boz/circumspect/resolver/podman"
+	"github.com/boz/circumspect/resolver/process"
+	"github.com/boz/circumspect/resolver/systemd"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// DefaultSocket is the path the server listens on and clients connect to by default.
+const DefaultSocket = "/tmp/circumspect.sock"
+
+// Config is the configuration of the server and the resolvers.  Values
+// not present in a config file retain their defaults.
+//
+//	resolvers: [cri, kube]
+//	cri:
+//	  endpoint: /var/run/crio/crio.sock
+//	kube:
+//	  namespace: ""
+//	  kubeconfig: /etc/kubernetes/kubeconfig
+//	listeners:
+//	  - address: /run/circumspect/circumspect.sock
+//	    mode: 0660
+//	    group: circumspect
+//	  - address: "@circumspect-untrusted"
+//	    label: untrusted
+//	    policy: /etc/circumspect/untrusted.yml
+//	server:
+//	  policy: /etc/circumspect/policy.yml
+//	  jwt:
+//	    issuer: node-1.example.com
+//	    claims: [kube-namespace, kube-service-account]
+//	  x509:
+//	    ca-cert: /etc/circumspect/ca.pem
+//	    ca-key: /etc/circumspect/ca.key
+type Config struct {
+	// Names of the resolvers to enable.
+	Resolvers []string `yaml:"resolvers"`
+
+	// Sockets the server listens on.  Overridden by --socket.
+	Listeners []Listener `yaml:"listeners"`
+
+	// Policy and identity issuance.  Overridden by the server's flags.
+	Server Server `yaml:"server"`
+
+	Docker     docker.Config     `yaml:"docker"`
+	Containerd containerd.Config `yaml:"containerd"`
+	Podman     podman.Config     `yaml:"podman"`
+	CRI        cri.Config        `yaml:"cri"`
+	Kube       kube.Config       `yaml:"kube"`
+	Systemd    systemd.Config    `yaml:"systemd"`
+	Process    process.Config    `yaml:"process"`
+}
+
+// Default returns the configuration used when no config file is given.
+func Default() Config {
+	return Config{
+		Resolvers:  []string{"docker"},
+		Listeners:  []Listener{{Address: DefaultSocket}},
+		Server:     DefaultServer(),
+		Docker:     docker.DefaultConfig(),
+		Containerd: containerd.DefaultConfig(),
+		Podman:     podman.DefaultConfig(),
+		CRI:        cri.DefaultConfig(),
+		Kube:       kube.DefaultConfig(),
+		Systemd:    systemd.DefaultConfig(),
+		Process:    process.DefaultConfig(),
+	}
+}
+
+// Load reads and validates a YAML config file.
+func Load(path string) (Config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+
+	c, err := Parse(data)
+	if err != nil {
+		return Config{}, fmt.Errorf("%v: %v", path, err)
+	}
+
+	return c, nil
+}
+
+// Parse decodes and validates a YAML config, applying defaults
+// for values that are not present.
+func Parse(data []byte) (Config, error) {
+	c := Default()
+
+	if err := yaml.UnmarshalStrict(data, &c); err != nil {
+		return Config{}, err
+	}
+
+	if err := c.Validate(); err != nil {
+		return Config{}, err
+	}
+
+	return c, nil
+}
+
+func (c Config) Validate() error {
+	if len(c.Resolvers) == 0 {
+		return errors.New("resolvers: at least one resolver required")
+	}
+
+	if len(c.Listeners) == 0 {
+		return errors.New("listeners: at least one listener required")
+	}
+
+	for idx, l := range c.Listeners {
+		if err := l.Validate(); err != nil {
+			return fmt.Errorf("listeners[%v].%v", idx, err)
+		}
+	}
+
+	if err := c.Server.Validate(); err != nil {
+		return fmt.Errorf("server.%v", err)
+	}
+
+	if err := c.Docker.Validate(); err != nil {
+		return fmt.Errorf("docker.%v", err)
+	}
+
+	if err := c.Containerd.Validate(); err != nil {
+		return fmt.Errorf("containerd.%v", err)
+	}
+
+	if err := c.Podman.Validate(); err != nil {
+		return fmt.Errorf("podman.%v", err)
+	}
+
+	if err := c.CRI.Validate(); err != nil {
+		return fmt.Errorf("cri.%v", err)
+	}
+
+	if err := c.Kube.Validate(); err != nil {
+		return fmt.Errorf("kube.%v", err)
+	}
+
+	if err := c.Systemd.Validate(); err != nil {
+		return fmt.Errorf("systemd.%v", err)
+	}
+
+	if err := c.Process.Validate(); err != nil {
+		return fmt.Errorf("process.%v", err)
+	}
+
+	return nil
+}
+
+// Marshal encodes the configuration as YAML.
+func (c Config) Marshal() ([]byte, error) {
+	return yaml.Marshal(c)
+}
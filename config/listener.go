@@ -0,0 +1,71 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/boz/circumspect/policy"
+	"github.com/boz/circumspect/rpc"
+)
+
+// Listener configures a socket that the server listens on.
+// See rpc.Listener for the address formats.
+type Listener struct {
+	Address string `yaml:"address"`
+
+	// Octal permissions, owner and group of a filesystem socket.
+	Mode  string `yaml:"mode,omitempty"`
+	Owner string `yaml:"owner,omitempty"`
+	Group string `yaml:"group,omitempty"`
+
+	// Value of the server-listener property of peers connected to the socket.
+	Label string `yaml:"label,omitempty"`
+
+	// Path of a policy file that must also allow peers connected to the socket.
+	Policy string `yaml:"policy,omitempty"`
+}
+
+func (l Listener) Validate() error {
+	rl, err := l.listener()
+	if err != nil {
+		return err
+	}
+	return rl.Validate()
+}
+
+// Listener returns the rpc listener, loading its policy file.
+func (l Listener) Listener() (rpc.Listener, error) {
+	rl, err := l.listener()
+	if err != nil {
+		return rpc.Listener{}, err
+	}
+
+	if l.Policy != "" {
+		if rl.Policy, err = policy.Load(l.Policy); err != nil {
+			return rpc.Listener{}, err
+		}
+	}
+
+	return rl, nil
+}
+
+func (l Listener) listener() (rpc.Listener, error) {
+	var mode uint64
+
+	if l.Mode != "" {
+		var err error
+		mode, err = strconv.ParseUint(l.Mode, 8, 32)
+		if err != nil || mode > 0777 {
+			return rpc.Listener{}, fmt.Errorf("mode: invalid permissions %q", l.Mode)
+		}
+	}
+
+	return rpc.Listener{
+		Address: l.Address,
+		Mode:    os.FileMode(mode),
+		Owner:   l.Owner,
+		Group:   l.Group,
+		Label:   l.Label,
+	}, nil
+}
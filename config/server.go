@@ -0,0 +1,138 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/boz/circumspect/identity/jwt"
+	"github.com/boz/circumspect/identity/x509ca"
+)
+
+const (
+	defaultJWTIssuer      = "circumspect"
+	defaultJWTKeyRotation = time.Hour
+)
+
+// Server configures the authorization policy of the server
+// and the identities it issues.
+type Server struct {
+	// Path of the authorization policy file.
+	Policy string `yaml:"policy,omitempty"`
+
+	// File that change events are appended to; "-" for stdout.
+	ChangeEvents string `yaml:"change-events,omitempty"`
+
+	JWT  JWT  `yaml:"jwt"`
+	X509 X509 `yaml:"x509"`
+}
+
+// JWT configures the identity tokens issued by the server.
+type JWT struct {
+	Issuer string `yaml:"issuer"`
+
+	// PEM private key for signing tokens.  If empty, an in-memory
+	// key is generated and rotated every KeyRotation.
+	Key         string        `yaml:"key,omitempty"`
+	KeyRotation time.Duration `yaml:"key-rotation"`
+
+	MaxTTL time.Duration `yaml:"max-ttl"`
+
+	// Properties included in tokens as claims.
+	Claims []string `yaml:"claims"`
+
+	// Address to serve the JWKS on over http, if any.
+	JWKSListen string `yaml:"jwks-listen,omitempty"`
+}
+
+// X509 configures the workload certificates issued by the server.
+// Certificates are only issued if a CA is given.
+type X509 struct {
+	CACert string `yaml:"ca-cert,omitempty"`
+	CAKey  string `yaml:"ca-key,omitempty"`
+
+	// SAN URI template; see x509ca.ParseURITemplate.
+	URITemplate string `yaml:"uri-template"`
+
+	MaxTTL time.Duration `yaml:"max-ttl"`
+}
+
+// DefaultServer returns the server configuration used
+// when none is given.
+func DefaultServer() Server {
+	return Server{
+		JWT: JWT{
+			Issuer:      defaultJWTIssuer,
+			KeyRotation: defaultJWTKeyRotation,
+			MaxTTL:      jwt.DefaultMaxTTL,
+			Claims:      jwt.DefaultClaims,
+		},
+		X509: X509{
+			URITemplate: x509ca.DefaultURITemplate,
+			MaxTTL:      x509ca.DefaultMaxTTL,
+		},
+	}
+}
+
+func (c Server) Validate() error {
+	if err := c.JWT.Validate(); err != nil {
+		return fmt.Errorf("jwt.%v", err)
+	}
+
+	if err := c.X509.Validate(); err != nil {
+		return fmt.Errorf("x509.%v", err)
+	}
+
+	return nil
+}
+
+func (c JWT) Validate() error {
+	if c.Issuer == "" {
+		return errors.New("issuer: required")
+	}
+
+	if c.KeyRotation <= 0 {
+		return fmt.Errorf("key-rotation: must be positive (got %v)", c.KeyRotation)
+	}
+
+	if c.MaxTTL <= 0 {
+		return fmt.Errorf("max-ttl: must be positive (got %v)", c.MaxTTL)
+	}
+
+	for _, name := range c.Claims {
+		if name == "" {
+			return errors.New("claims: empty property name")
+		}
+	}
+
+	// tokens must remain verifiable until they expire.
+	if c.Key == "" && c.MaxTTL > c.KeyRotation {
+		return fmt.Errorf("max-ttl: must not exceed key-rotation (%v > %v)", c.MaxTTL, c.KeyRotation)
+	}
+
+	return nil
+}
+
+func (c X509) Validate() error {
+	if c.CACert != "" && c.CAKey == "" {
+		return errors.New("ca-key: required with ca-cert")
+	}
+
+	if c.CAKey != "" && c.CACert == "" {
+		return errors.New("ca-cert: required with ca-key")
+	}
+
+	if c.URITemplate == "" {
+		return errors.New("uri-template: required")
+	}
+
+	if _, err := x509ca.ParseURITemplate(c.URITemplate); err != nil {
+		return fmt.Errorf("uri-template: %v", err)
+	}
+
+	if c.MaxTTL <= 0 {
+		return fmt.Errorf("max-ttl: must be positive (got %v)", c.MaxTTL)
+	}
+
+	return nil
+}
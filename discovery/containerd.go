@@ -0,0 +1,56 @@
+package discovery
+
+import (
+	"context"
+
+	"github.com/boz/circumspect/config"
+	"github.com/boz/circumspect/propset"
+	"github.com/boz/circumspect/resolver/containerd"
+	"github.com/boz/circumspect/resolver/uds"
+)
+
+func init() {
+	Register(Plugin{
+		Name: "containerd",
+		New: func(ctx context.Context, cfg config.Config) (Resolver, error) {
+			svc, err := containerd.NewService(ctx, cfg.Containerd)
+			if err != nil {
+				return nil, err
+			}
+			return &containerdResolver{svc}, nil
+		},
+	})
+}
+
+type containerdResolver struct {
+	svc containerd.Service
+}
+
+func (r *containerdResolver) Lookup(ctx context.Context, pprops uds.PidProps, _ propset.PropSet) (propset.PropSet, error) {
+	props, err := r.svc.Lookup(ctx, pprops)
+	switch err {
+	case nil:
+		return props.PropSet(), nil
+	case containerd.ErrNotFound:
+		return nil, NotApplicable(err)
+	default:
+		return nil, err
+	}
+}
+
+func (r *containerdResolver) Watch(ctx context.Context, pset propset.PropSet) <-chan struct{} {
+	ns := propString(pset, "containerd-namespace")
+	id := propString(pset, "containerd-id")
+	if ns == "" || id == "" {
+		return nil
+	}
+	return r.svc.Watch(ctx, ns, id)
+}
+
+func (r *containerdResolver) Ready() <-chan struct{} {
+	return r.svc.Ready()
+}
+
+func (r *containerdResolver) Shutdown() {
+	r.svc.Shutdown()
+}
@@ -0,0 +1,52 @@
+package discovery
+
+import (
+	"context"
+
+	"github.com/boz/circumspect/config"
+	"github.com/boz/circumspect/propset"
+	"github.com/boz/circumspect/resolver/cri"
+	"github.com/boz/circumspect/resolver/uds"
+)
+
+func init() {
+	Register(Plugin{
+		Name: "cri",
+		New: func(ctx context.Context, cfg config.Config) (Resolver, error) {
+			svc, err := cri.NewService(ctx, cfg.CRI)
+			if err != nil {
+				return nil, err
+			}
+			return &criResolver{svc}, nil
+		},
+	})
+}
+
+type criResolver struct {
+	svc cri.Service
+}
+
+func (r *criResolver) Lookup(ctx context.Context, pprops uds.PidProps, _ propset.PropSet) (propset.PropSet, error) {
+	props, err := r.svc.Lookup(ctx, pprops)
+	switch err {
+	case nil:
+		return props.PropSet(), nil
+	case cri.ErrNotFound:
+		return nil, NotApplicable(err)
+	default:
+		return nil, err
+	}
+}
+
+// Watch is not supported; the runtime doesn't provide container events.
+func (r *criResolver) Watch(ctx context.Context, pset propset.PropSet) <-chan struct{} {
+	return nil
+}
+
+func (r *criResolver) Ready() <-chan struct{} {
+	return r.svc.Ready()
+}
+
+func (r *criResolver) Shutdown() {
+	r.svc.Shutdown()
+}
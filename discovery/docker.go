@@ -0,0 +1,73 @@
+package discovery
+
+import (
+	"context"
+
+	"github.com/boz/circumspect/config"
+	"github.com/boz/circumspect/propset"
+	"github.com/boz/circumspect/resolver/docker"
+	"github.com/boz/circumspect/resolver/uds"
+)
+
+func init() {
+	Register(Plugin{
+		Name: "docker",
+		New: func(ctx context.Context, cfg config.Config) (Resolver, error) {
+			svc, err := docker.NewService(ctx, cfg.Docker)
+			if err != nil {
+				return nil, err
+			}
+			return &dockerResolver{svc}, nil
+		},
+	})
+}
+
+type dockerResolver struct {
+	svc docker.Service
+}
+
+func (r *dockerResolver) Lookup(ctx context.Context, pprops uds.PidProps, _ propset.PropSet) (propset.PropSet, error) {
+	props, err := r.svc.Lookup(ctx, pprops)
+	switch err {
+	case nil:
+		return props.PropSet(), nil
+	case docker.ErrNotFound:
+		return nil, NotApplicable(err)
+	default:
+		return nil, err
+	}
+}
+
+func (r *dockerResolver) Watch(ctx context.Context, pset propset.PropSet) <-chan struct{} {
+	id := propString(pset, "docker-id")
+	if id == "" {
+		return nil
+	}
+	return r.svc.Watch(ctx, id)
+}
+
+func (r *dockerResolver) Ready() <-chan struct{} {
+	return r.svc.Ready()
+}
+
+func (r *dockerResolver) Shutdown() {
+	r.svc.Shutdown()
+}
+
+// propString returns the value of the named property, or
+// the empty string if it is not present.
+func propString(pset propset.PropSet, name string) string {
+	if prop, ok := pset[name]; ok {
+		return prop.String()
+	}
+	return ""
+}
+
+// propMap returns the value of the named map property, or
+// nil if it is not present.
+func propMap(pset propset.PropSet, name string) map[string]string {
+	if prop, ok := pset[name].(propset.Map); ok {
+		return prop.Strings()
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package grpc
+
+import (
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/boz/circumspect/discovery"
+	"github.com/boz/circumspect/propset"
+	"github.com/boz/circumspect/resolver/uds"
+	udsgrpc "github.com/boz/circumspect/resolver/uds/grpc"
+	"github.com/sirupsen/logrus"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+var pkglog = logrus.StandardLogger().WithField("package", "discovery/grpc")
+
+// CacheTTL is how long the properties of a connection are reused before
+// being resolved again, so that container and pod changes are seen by
+// long-lived connections.
+const CacheTTL = 30 * time.Second
+
+type propSetKey struct{}
+
+// ServerOptions returns the options needed for a gRPC server to
+// resolve its unix socket peers with strategy.
+func ServerOptions(strategy discovery.Strategy, opts ...udsgrpc.Option) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.Creds(udsgrpc.NewCredentials(opts...)),
+		grpc.UnaryInterceptor(UnaryServerInterceptor(strategy)),
+		grpc.StreamInterceptor(StreamServerInterceptor(strategy)),
+	}
+}
+
+// UnaryServerInterceptor resolves the caller's properties with strategy and
+// makes them available to handlers via PropSetFromContext.
+//
+// The server must use the credentials from resolver/uds/grpc.  Properties
+// are resolved once per connection and cached for CacheTTL.
+// Unauthenticated peers are rejected as by udsgrpc.UnaryServerInterceptor;
+// if anonymous peers are allowed they reach the handler without properties.
+func UnaryServerInterceptor(strategy discovery.Strategy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := resolve(ctx, strategy, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is the streaming equivalent of UnaryServerInterceptor.
+func StreamServerInterceptor(strategy discovery.Strategy) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := resolve(ss.Context(), strategy, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ss, ctx})
+	}
+}
+
+// PropSetFromContext returns the caller's properties as resolved by the interceptors.
+func PropSetFromContext(ctx context.Context) (propset.PropSet, bool) {
+	pset, ok := ctx.Value(propSetKey{}).(propset.PropSet)
+	return pset, ok
+}
+
+func resolve(ctx context.Context, strategy discovery.Strategy, method string) (context.Context, error) {
+	log := pkglog.WithField("method", method)
+
+	if err := udsgrpc.Authenticate(ctx); err != nil {
+		log.WithError(err).Warn("rejecting peer")
+		return nil, err
+	}
+
+	p, _ := peer.FromContext(ctx)
+
+	pset, ok, err := udsgrpc.CachedPropSet(ctx, p.AuthInfo, CacheTTL, func(ctx context.Context, props uds.Props) (propset.PropSet, error) {
+		log.WithField("pid", props.Pid()).Debug("resolving peer")
+		return strategy.Lookup(ctx, props)
+	})
+
+	switch {
+	case !ok:
+		log.WithError(udsgrpc.ErrorFromAuthInfo(p.AuthInfo)).Debug("anonymous peer")
+		return ctx, nil
+	case uds.IsProcessChanged(err):
+		log.WithError(err).Warn("rejecting peer")
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	case err == context.DeadlineExceeded:
+		return nil, status.Error(codes.DeadlineExceeded, err.Error())
+	case err == context.Canceled:
+		return nil, status.Error(codes.Canceled, err.Error())
+	case err != nil:
+		log.WithError(err).Warn("error resolving peer")
+		return nil, status.Error(codes.Unavailable, "error resolving peer")
+	}
+
+	return context.WithValue(ctx, propSetKey{}, pset), nil
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
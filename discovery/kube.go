@@ -0,0 +1,79 @@
+package discovery
+
+import (
+	"context"
+
+	"github.com/boz/circumspect/config"
+	"github.com/boz/circumspect/propset"
+	"github.com/boz/circumspect/resolver/kube"
+	"github.com/boz/circumspect/resolver/uds"
+)
+
+func init() {
+	Register(Plugin{
+		Name:    "kube",
+		AnyDeps: []string{"docker", "cri"},
+		New: func(ctx context.Context, cfg config.Config) (Resolver, error) {
+			svc, err := kube.NewService(ctx, cfg.Kube)
+			if err != nil {
+				return nil, err
+			}
+			return &kubeResolver{svc}, nil
+		},
+	})
+}
+
+type kubeResolver struct {
+	svc kube.Service
+}
+
+func (r *kubeResolver) Lookup(ctx context.Context, _ uds.PidProps, pset propset.PropSet) (propset.PropSet, error) {
+	props, err := r.svc.Lookup(ctx, kubeRequiredProps{pset})
+	switch err {
+	case nil:
+		return props.PropSet(), nil
+	case kube.ErrContainerNotRecognized:
+		return nil, NotApplicable(err)
+	default:
+		return nil, err
+	}
+}
+
+func (r *kubeResolver) Watch(ctx context.Context, pset propset.PropSet) <-chan struct{} {
+	// watch even if the pod wasn't found; it may not have been synced yet.
+	if (kubeRequiredProps{pset}).ContainerID() == "" {
+		return nil
+	}
+	return r.svc.Watch(ctx, kubeRequiredProps{pset})
+}
+
+func (r *kubeResolver) Ready() <-chan struct{} {
+	return r.svc.Ready()
+}
+
+func (r *kubeResolver) Shutdown() {
+	r.svc.Shutdown()
+}
+
+// kubeRequiredProps provides the container properties needed by the
+// kube resolver from a PropSet resolved by either docker or a CRI runtime.
+type kubeRequiredProps struct {
+	pset propset.PropSet
+}
+
+func (p kubeRequiredProps) ContainerID() string {
+	if id := propString(p.pset, "docker-id"); id != "" {
+		return "docker://" + id
+	}
+	if id := propString(p.pset, "cri-container-id"); id != "" {
+		return propString(p.pset, "cri-runtime") + "://" + id
+	}
+	return ""
+}
+
+func (p kubeRequiredProps) ContainerLabels() map[string]string {
+	if labels := propMap(p.pset, "docker-labels"); labels != nil {
+		return labels
+	}
+	return propMap(p.pset, "cri-labels")
+}
@@ -0,0 +1,55 @@
+package discovery
+
+import (
+	"context"
+
+	"github.com/boz/circumspect/config"
+	"github.com/boz/circumspect/propset"
+	"github.com/boz/circumspect/resolver/podman"
+	"github.com/boz/circumspect/resolver/uds"
+)
+
+func init() {
+	Register(Plugin{
+		Name: "podman",
+		New: func(ctx context.Context, cfg config.Config) (Resolver, error) {
+			svc, err := podman.NewService(ctx, cfg.Podman)
+			if err != nil {
+				return nil, err
+			}
+			return &podmanResolver{svc}, nil
+		},
+	})
+}
+
+type podmanResolver struct {
+	svc podman.Service
+}
+
+func (r *podmanResolver) Lookup(ctx context.Context, pprops uds.PidProps, _ propset.PropSet) (propset.PropSet, error) {
+	props, err := r.svc.Lookup(ctx, pprops)
+	switch err {
+	case nil:
+		return props.PropSet(), nil
+	case podman.ErrNotFound:
+		return nil, NotApplicable(err)
+	default:
+		return nil, err
+	}
+}
+
+func (r *podmanResolver) Watch(ctx context.Context, pset propset.PropSet) <-chan struct{} {
+	id := propString(pset, "podman-id")
+	if id == "" {
+		return nil
+	}
+	return r.svc.Watch(ctx, id)
+}
+
+func (r *podmanResolver) Ready() <-chan struct{} {
+	return r.svc.Ready()
+}
+
+func (r *podmanResolver) Shutdown() {
+	r.svc.Shutdown()
+}
@@ -0,0 +1,48 @@
+package discovery
+
+import (
+	"context"
+
+	"github.com/boz/circumspect/config"
+	"github.com/boz/circumspect/propset"
+	"github.com/boz/circumspect/resolver/process"
+	"github.com/boz/circumspect/resolver/uds"
+)
+
+func init() {
+	Register(Plugin{
+		Name: "process",
+		New: func(ctx context.Context, cfg config.Config) (Resolver, error) {
+			svc, err := process.NewService(ctx, cfg.Process)
+			if err != nil {
+				return nil, err
+			}
+			return &processResolver{svc}, nil
+		},
+	})
+}
+
+type processResolver struct {
+	svc process.Service
+}
+
+func (r *processResolver) Lookup(ctx context.Context, pprops uds.PidProps, _ propset.PropSet) (propset.PropSet, error) {
+	props, err := r.svc.Lookup(ctx, pprops)
+	if err != nil {
+		return nil, err
+	}
+	return props.PropSet(), nil
+}
+
+// Watch is not supported; credentials changed by the process itself are not tracked.
+func (r *processResolver) Watch(ctx context.Context, pset propset.PropSet) <-chan struct{} {
+	return nil
+}
+
+func (r *processResolver) Ready() <-chan struct{} {
+	return r.svc.Ready()
+}
+
+func (r *processResolver) Shutdown() {
+	r.svc.Shutdown()
+}
@@ -0,0 +1,151 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/boz/circumspect/config"
+	"github.com/boz/circumspect/propset"
+	"github.com/boz/circumspect/resolver/uds"
+)
+
+// Resolver is a source of process properties.
+type Resolver interface {
+	// Lookup returns the properties of the given process.  pset contains
+	// the properties resolved so far, including those of the resolver's
+	// dependencies.  Errors wrapped with NotApplicable indicate that the
+	// resolver does not apply to the process.
+	Lookup(ctx context.Context, pprops uds.PidProps, pset propset.PropSet) (propset.PropSet, error)
+
+	// Watch returns a channel that is signalled when the properties
+	// resolved for pset may have changed, or nil if they can't be watched.
+	// The watch is removed when the given context is cancelled.
+	Watch(ctx context.Context, pset propset.PropSet) <-chan struct{}
+
+	// Ready is closed once the resolver has loaded its initial state.
+	Ready() <-chan struct{}
+
+	Shutdown()
+}
+
+// Plugin describes a resolver that can be enabled by name.
+type Plugin struct {
+	Name string
+
+	// Names of the resolvers whose properties this resolver requires.
+	// They are run before this one.
+	Deps []string
+
+	// Names of resolvers, at least one of which must be enabled, that
+	// can provide the properties this resolver requires.  Those that
+	// are enabled are run before this one.
+	AnyDeps []string
+
+	New func(context.Context, config.Config) (Resolver, error)
+}
+
+var (
+	plugins    = make(map[string]Plugin)
+	pluginsMtx sync.Mutex
+)
+
+// Register makes a resolver available to Build.  It panics
+// if a resolver with the same name is already registered.
+func Register(p Plugin) {
+	pluginsMtx.Lock()
+	defer pluginsMtx.Unlock()
+
+	if _, ok := plugins[p.Name]; ok {
+		panic(fmt.Sprintf("discovery: resolver %q registered twice", p.Name))
+	}
+	plugins[p.Name] = p
+}
+
+// Plugins returns the names of all registered resolvers.
+func Plugins() []string {
+	pluginsMtx.Lock()
+	defer pluginsMtx.Unlock()
+
+	names := make([]string, 0, len(plugins))
+	for name := range plugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// orderPlugins returns the named plugins ordered so that each
+// one follows its dependencies.
+func orderPlugins(names []string) ([]Plugin, error) {
+	pluginsMtx.Lock()
+	defer pluginsMtx.Unlock()
+
+	enabled := make(map[string]Plugin)
+
+	for _, name := range names {
+		p, ok := plugins[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown resolver %q", name)
+		}
+		enabled[name] = p
+	}
+
+	var ordered []Plugin
+
+	// 0: unvisited, 1: visiting, 2: done
+	state := make(map[string]int)
+
+	var visit func(p Plugin) error
+	visit = func(p Plugin) error {
+		switch state[p.Name] {
+		case 1:
+			return fmt.Errorf("resolver %q has a circular dependency", p.Name)
+		case 2:
+			return nil
+		}
+
+		state[p.Name] = 1
+
+		for _, dep := range p.Deps {
+			dp, ok := enabled[dep]
+			if !ok {
+				return fmt.Errorf("%v resolver requires %v to be enabled", p.Name, dep)
+			}
+			if err := visit(dp); err != nil {
+				return err
+			}
+		}
+
+		found := false
+		for _, dep := range p.AnyDeps {
+			dp, ok := enabled[dep]
+			if !ok {
+				continue
+			}
+			found = true
+			if err := visit(dp); err != nil {
+				return err
+			}
+		}
+
+		if len(p.AnyDeps) > 0 && !found {
+			return fmt.Errorf("%v resolver requires one of %v to be enabled",
+				p.Name, strings.Join(p.AnyDeps, ", "))
+		}
+
+		state[p.Name] = 2
+		ordered = append(ordered, p)
+		return nil
+	}
+
+	for _, name := range names {
+		if err := visit(enabled[name]); err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
+}
@@ -0,0 +1,211 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/boz/circumspect/propset"
+)
+
+const (
+	// Map of resolver name to Status.
+	PropResolverStatus = "resolver-status"
+
+	// Map of resolver name to error text for resolvers that did not resolve.
+	PropResolverErrors = "resolver-errors"
+)
+
+// Status is the outcome of a single resolver's lookup.
+type Status string
+
+const (
+	// The resolver found properties for the process.
+	StatusResolved Status = "resolved"
+
+	// The resolver determined that it does not apply to the process;
+	// e.g. the process is not running in a container.
+	StatusNotApplicable Status = "not-applicable"
+
+	// The resolver did not complete in time.
+	StatusTimedOut Status = "timed-out"
+
+	// The resolver failed.
+	StatusError Status = "error"
+)
+
+// Definite is true if the status is a conclusive answer.
+func (s Status) Definite() bool {
+	return s == StatusResolved || s == StatusNotApplicable
+}
+
+// ResolverStatus records the outcome of a single resolver.
+type ResolverStatus struct {
+	Name   string
+	Status Status
+	Err    error
+}
+
+func (rs ResolverStatus) String() string {
+	if rs.Err == nil {
+		return fmt.Sprintf("%v: %v", rs.Name, rs.Status)
+	}
+	return fmt.Sprintf("%v: %v: %v", rs.Name, rs.Status, rs.Err)
+}
+
+// NotApplicable wraps err to indicate that a resolver does not
+// apply to a process; e.g. the process is not in a container.
+func NotApplicable(err error) error {
+	return &notApplicableError{err}
+}
+
+type notApplicableError struct {
+	err error
+}
+
+func (e *notApplicableError) Error() string {
+	return e.err.Error()
+}
+
+func newResolverStatus(name string, err error) ResolverStatus {
+	rs := ResolverStatus{Name: name, Status: StatusResolved, Err: err}
+
+	switch e := err.(type) {
+	case nil:
+	case *notApplicableError:
+		rs.Status = StatusNotApplicable
+		rs.Err = e.err
+	default:
+		if isTimeout(err) {
+			rs.Status = StatusTimedOut
+		} else {
+			rs.Status = StatusError
+		}
+	}
+
+	return rs
+}
+
+// isTimeout returns true if err is context.DeadlineExceeded or
+// reports itself as a timeout (e.g. registry.LookupTimeoutError).
+func isTimeout(err error) bool {
+	if err == context.DeadlineExceeded {
+		return true
+	}
+	t, ok := err.(interface {
+		Timeout() bool
+	})
+	return ok && t.Timeout()
+}
+
+// LookupError is returned by Strategy.Lookup when one or more
+// resolvers could not determine whether they apply to a process.
+type LookupError struct {
+	Statuses []ResolverStatus
+}
+
+func (e *LookupError) Error() string {
+	var msgs []string
+	for _, rs := range e.Statuses {
+		if !rs.Status.Definite() {
+			msgs = append(msgs, rs.String())
+		}
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// TimedOut is true if every failed resolver timed out.
+func (e *LookupError) TimedOut() bool {
+	for _, rs := range e.Statuses {
+		if !rs.Status.Definite() && rs.Status != StatusTimedOut {
+			return false
+		}
+	}
+	return true
+}
+
+// StatusesFromPropSet returns the resolver statuses recorded in pset.
+func StatusesFromPropSet(pset propset.PropSet) map[string]Status {
+	statuses := make(map[string]Status)
+
+	if m, ok := pset[PropResolverStatus].(propset.Map); ok {
+		for name, status := range m.Strings() {
+			statuses[name] = Status(status)
+		}
+	}
+
+	return statuses
+}
+
+// CheckComplete returns a *LookupError if any resolver status recorded
+// in pset is not definite.  Properties delivered by Strategy.Watch may be
+// incomplete; this allows callers to reject them as Lookup would.
+func CheckComplete(pset propset.PropSet) error {
+	statuses := StatusesFromPropSet(pset)
+
+	var errs map[string]string
+	if m, ok := pset[PropResolverErrors].(propset.Map); ok {
+		errs = m.Strings()
+	}
+
+	names := make([]string, 0, len(statuses))
+	for name := range statuses {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	failed := false
+	rstatuses := make([]ResolverStatus, 0, len(names))
+
+	for _, name := range names {
+		rs := ResolverStatus{Name: name, Status: statuses[name]}
+		if text, ok := errs[name]; ok {
+			rs.Err = errors.New(text)
+		}
+		if !rs.Status.Definite() {
+			failed = true
+		}
+		rstatuses = append(rstatuses, rs)
+	}
+
+	if failed {
+		return &LookupError{rstatuses}
+	}
+
+	return nil
+}
+
+// addStatuses records the resolver statuses in pset and returns
+// a *LookupError if any of them are not definite.
+func addStatuses(pset propset.PropSet, statuses []ResolverStatus) error {
+	smap := make(map[string]string)
+	emap := make(map[string]string)
+
+	failed := false
+
+	for _, rs := range statuses {
+		smap[rs.Name] = string(rs.Status)
+		if rs.Err != nil {
+			emap[rs.Name] = rs.Err.Error()
+		}
+		if !rs.Status.Definite() {
+			failed = true
+		}
+	}
+
+	if len(smap) > 0 {
+		pset.AddMap(PropResolverStatus, smap)
+	}
+
+	if len(emap) > 0 {
+		pset.AddMap(PropResolverErrors, emap)
+	}
+
+	if failed {
+		return &LookupError{statuses}
+	}
+
+	return nil
+}
@@ -2,95 +2,262 @@ package discovery
 
 import (
 	"context"
-	"errors"
+	"fmt"
+	"reflect"
+	"strings"
 	"sync"
 
+	"github.com/boz/circumspect/config"
 	"github.com/boz/circumspect/propset"
-	"github.com/boz/circumspect/resolver/docker"
-	"github.com/boz/circumspect/resolver/kube"
+	"github.com/boz/circumspect/resolver/cgroup"
 	"github.com/boz/circumspect/resolver/uds"
+	"github.com/sirupsen/logrus"
 )
 
+var pkglog = logrus.StandardLogger().WithField("package", "discovery")
+
+// PropSystemContainer is true if the cgroup of the process names a
+// container, whichever runtime runs it and whether or not that
+// runtime's resolver is enabled.  It is missing if the cgroup couldn't
+// be read.
+const PropSystemContainer = "system-container"
+
 type Strategy interface {
+	// Lookup resolves the properties of the given process.  The status of
+	// each resolver is recorded in the PropResolverStatus property.
+	//
+	// If any resolver could not determine whether it applies to the process
+	// a *LookupError is returned along with the partial PropSet.
+	//
+	// If the process exited or its PID was reused during the lookup
+	// a *uds.ProcessChangedError is returned and no PropSet.
 	Lookup(context.Context, uds.PidProps) (propset.PropSet, error)
+
+	// Watch resolves the given process and delivers its properties,
+	// followed by updated properties each time its container or pod changes.
+	// The returned channel is closed when the context is cancelled
+	// or the process exits.
+	Watch(context.Context, uds.PidProps) <-chan propset.PropSet
+
+	// Ready is closed once all resolvers are ready.
+	Ready() <-chan struct{}
+
 	Shutdown()
 }
 
-func Build(ctx context.Context, enableDocker bool, enableKube bool) (Strategy, error) {
-
-	if enableKube && !enableDocker {
-		return nil, errors.New("kube resolver requires docker to be enabled")
+// Build creates a Strategy from the resolvers named in cfg.  Resolvers are
+// run after the resolvers they depend on, which must also be named.
+func Build(ctx context.Context, cfg config.Config) (Strategy, error) {
+	plugins, err := orderPlugins(cfg.Resolvers)
+	if err != nil {
+		return nil, err
 	}
 
-	s := &strategy{}
-	var err error
+	s := &strategy{readych: make(chan struct{})}
 
-	if enableDocker {
-		s.docker, err = docker.NewService(ctx)
+	for _, p := range plugins {
+		r, err := p.New(ctx, cfg)
 		if err != nil {
-			return nil, err
+			s.Shutdown()
+			return nil, fmt.Errorf("%v resolver: %v", p.Name, err)
 		}
+		s.resolvers = append(s.resolvers, namedResolver{p, r})
 	}
 
-	if enableKube {
-		s.kube, err = kube.NewService(ctx)
-		if err != nil {
-			s.docker.Shutdown()
-			return nil, err
-		}
-	}
+	go s.waitReady(ctx)
 
 	return s, nil
 }
 
+type namedResolver struct {
+	Plugin
+	Resolver
+}
+
 type strategy struct {
-	docker docker.Service
-	kube   kube.Service
+	resolvers []namedResolver
+	readych   chan struct{}
 }
 
-func (d *strategy) Shutdown() {
-	var wg sync.WaitGroup
+func (d *strategy) Ready() <-chan struct{} {
+	return d.readych
+}
 
-	if d.docker != nil {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			d.docker.Shutdown()
-		}()
+func (d *strategy) waitReady(ctx context.Context) {
+	for _, r := range d.resolvers {
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.Ready():
+		}
 	}
+	close(d.readych)
+}
 
-	if d.kube != nil {
+func (d *strategy) Shutdown() {
+	var wg sync.WaitGroup
+
+	for _, r := range d.resolvers {
 		wg.Add(1)
-		go func() {
+		go func(r namedResolver) {
 			defer wg.Done()
-			d.kube.Shutdown()
-		}()
+			r.Shutdown()
+		}(r)
 	}
 
 	wg.Wait()
 }
 
 func (d *strategy) Lookup(ctx context.Context, pprops uds.PidProps) (propset.PropSet, error) {
-	var err error
-	var dprops docker.Props
+	pset, _, err := d.lookup(ctx, pprops)
+	return pset, err
+}
+
+func (d *strategy) Watch(ctx context.Context, pprops uds.PidProps) <-chan propset.PropSet {
+	log := pkglog.WithField("component", "watch").WithField("pid", pprops.Pid())
+
+	ch := make(chan propset.PropSet)
+
+	go func() {
+		defer close(ch)
+		defer log.Debug("done")
+
+		var last propset.PropSet
+
+		for {
+			pset, statuses, err := d.lookup(ctx, pprops)
+			if uds.IsProcessChanged(err) {
+				log.WithError(err).Info("process changed")
+				return
+			}
+			if err != nil {
+				log.WithError(err).Debug("incomplete lookup")
+			}
+
+			if !reflect.DeepEqual(pset, last) {
+				select {
+				case <-ctx.Done():
+					return
+				case ch <- pset:
+				}
+				last = pset
+			}
+
+			wctx, cancel := context.WithCancel(ctx)
 
+			changech := make(chan string, 1)
+
+			for idx, r := range d.resolvers {
+				if statuses[idx].Status == StatusNotApplicable {
+					continue
+				}
+
+				rch := r.Watch(wctx, pset)
+				if rch == nil {
+					continue
+				}
+
+				go func(name string) {
+					select {
+					case <-wctx.Done():
+					case <-rch:
+						select {
+						case changech <- name:
+						default:
+						}
+					}
+				}(r.Name)
+			}
+
+			select {
+			case <-ctx.Done():
+				cancel()
+				return
+			case name := <-changech:
+				log.WithField("resolver", name).Debug("properties changed")
+			}
+
+			cancel()
+		}
+	}()
+
+	return ch
+}
+
+// lookup resolves the properties of pprops.  The status of each
+// resolver is returned, in order, so that callers can watch for changes.
+func (d *strategy) lookup(ctx context.Context, pprops uds.PidProps) (propset.PropSet, []ResolverStatus, error) {
 	pset := pprops.PropSet()
 
-	if d.docker != nil {
-		dprops, err = d.docker.Lookup(ctx, pprops)
-		if err != nil {
-			return pset, nil
+	switch _, err := cgroup.ForPid(pprops.Pid()); err {
+	case nil:
+		pset.AddBool(PropSystemContainer, true)
+	case cgroup.ErrNotFound:
+		pset.AddBool(PropSystemContainer, false)
+	}
+
+	statuses := make([]ResolverStatus, 0, len(d.resolvers))
+	byName := make(map[string]ResolverStatus)
+
+	for _, r := range d.resolvers {
+		rs, ok := checkDeps(r.Plugin, byName)
+
+		if ok {
+			props, err := r.Lookup(ctx, pprops, pset)
+			if err == nil {
+				pset.Merge(props)
+			}
+			rs = newResolverStatus(r.Name, err)
 		}
-		pset.Merge(dprops.PropSet())
+
+		statuses = append(statuses, rs)
+		byName[r.Name] = rs
 	}
 
-	if d.kube != nil {
-		kprops, err := d.kube.Lookup(ctx, dprops)
-		if err != nil {
-			return pset, nil
+	// the properties were resolved by PID; make sure that
+	// they are of the process that was asked about.
+	if err := pprops.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	return pset, statuses, addStatuses(pset, statuses)
+}
+
+// checkDeps returns false, along with the status to record,
+// if the dependencies of p were not resolved.
+func checkDeps(p Plugin, statuses map[string]ResolverStatus) (ResolverStatus, bool) {
+	for _, dep := range p.Deps {
+		switch statuses[dep].Status {
+		case StatusResolved:
+		case StatusNotApplicable:
+			return ResolverStatus{Name: p.Name, Status: StatusNotApplicable}, false
+		default:
+			return newResolverStatus(p.Name, fmt.Errorf("%v properties unavailable", dep)), false
+		}
+	}
+
+	if len(p.AnyDeps) == 0 {
+		return ResolverStatus{}, true
+	}
+
+	// at least one enabled dependency must have resolved.
+	var failed []string
+
+	for _, dep := range p.AnyDeps {
+		rs, ok := statuses[dep]
+		switch {
+		case !ok:
+		case rs.Status == StatusResolved:
+			return ResolverStatus{}, true
+		case rs.Status != StatusNotApplicable:
+			failed = append(failed, dep)
 		}
-		pset.Merge(kprops.PropSet())
 	}
 
-	return pset, nil
+	if len(failed) > 0 {
+		err := fmt.Errorf("%v properties unavailable", strings.Join(failed, ", "))
+		return newResolverStatus(p.Name, err), false
+	}
+
+	return ResolverStatus{Name: p.Name, Status: StatusNotApplicable}, false
 }
@@ -0,0 +1,52 @@
+package discovery
+
+import (
+	"context"
+
+	"github.com/boz/circumspect/config"
+	"github.com/boz/circumspect/propset"
+	"github.com/boz/circumspect/resolver/systemd"
+	"github.com/boz/circumspect/resolver/uds"
+)
+
+func init() {
+	Register(Plugin{
+		Name: "systemd",
+		New: func(ctx context.Context, cfg config.Config) (Resolver, error) {
+			svc, err := systemd.NewService(ctx, cfg.Systemd)
+			if err != nil {
+				return nil, err
+			}
+			return &systemdResolver{svc}, nil
+		},
+	})
+}
+
+type systemdResolver struct {
+	svc systemd.Service
+}
+
+func (r *systemdResolver) Lookup(ctx context.Context, pprops uds.PidProps, _ propset.PropSet) (propset.PropSet, error) {
+	props, err := r.svc.Lookup(ctx, pprops)
+	switch err {
+	case nil:
+		return props.PropSet(), nil
+	case systemd.ErrNotFound:
+		return nil, NotApplicable(err)
+	default:
+		return nil, err
+	}
+}
+
+// Watch is not supported; a process doesn't move between units.
+func (r *systemdResolver) Watch(ctx context.Context, pset propset.PropSet) <-chan struct{} {
+	return nil
+}
+
+func (r *systemdResolver) Ready() <-chan struct{} {
+	return r.svc.Ready()
+}
+
+func (r *systemdResolver) Shutdown() {
+	r.svc.Shutdown()
+}
@@ -0,0 +1,146 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+)
+
+var ErrInvalidJWK = errors.New("invalid JWK")
+
+// JWKS is a JSON Web Key Set (RFC 7517) containing public keys only.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+
+	// EC
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+}
+
+func NewJWKS(keys []*Key) JWKS {
+	jwks := JWKS{Keys: make([]JWK, 0, len(keys))}
+
+	for _, key := range keys {
+		jwk := JWK{Kid: key.ID(), Use: "sig", Alg: key.Algorithm()}
+
+		switch pub := key.Public().(type) {
+		case *ecdsa.PublicKey:
+			size := (pub.Curve.Params().BitSize + 7) / 8
+			x := make([]byte, size)
+			y := make([]byte, size)
+			copyPadded(x, pub.X.Bytes())
+			copyPadded(y, pub.Y.Bytes())
+
+			jwk.Kty = "EC"
+			jwk.Crv = pub.Curve.Params().Name
+			jwk.X = base64.RawURLEncoding.EncodeToString(x)
+			jwk.Y = base64.RawURLEncoding.EncodeToString(y)
+
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+
+		default:
+			continue
+		}
+
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+
+	return jwks
+}
+
+// ParseJWKS decodes a JSON-encoded key set.
+func ParseJWKS(data []byte) (JWKS, error) {
+	var jwks JWKS
+	err := json.Unmarshal(data, &jwks)
+	return jwks, err
+}
+
+func (ks JWKS) find(kid string) (JWK, bool) {
+	for _, jwk := range ks.Keys {
+		if jwk.Kid == kid {
+			return jwk, true
+		}
+	}
+	return JWK{}, false
+}
+
+// PublicKey decodes the public key described by the JWK.
+func (k JWK) PublicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		default:
+			return nil, ErrInvalidJWK
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, nil
+
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+
+	default:
+		return nil, ErrInvalidJWK
+	}
+}
+
+// NewJWKSHandler serves the current verification keys of the
+// given source as a JSON Web Key Set.
+func NewJWKSHandler(source KeySource) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(NewJWKS(source.VerificationKeys())); err != nil {
+			pkglog.WithError(err).Warn("error writing JWKS")
+		}
+	})
+}
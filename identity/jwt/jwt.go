@@ -0,0 +1,244 @@
+package jwt
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/boz/circumspect/propset"
+)
+
+const (
+	DefaultTTL    = 5 * time.Minute
+	DefaultMaxTTL = time.Hour
+)
+
+var (
+	ErrInvalidAudience  = errors.New("audience required")
+	ErrInvalidTTL       = errors.New("invalid TTL")
+	ErrMalformedToken   = errors.New("malformed token")
+	ErrUnknownKey       = errors.New("unknown signing key")
+	ErrInvalidSignature = errors.New("invalid signature")
+	ErrExpired          = errors.New("token expired")
+	ErrWrongAudience    = errors.New("audience mismatch")
+)
+
+// DefaultClaims are the properties included in tokens unless others
+// are given: those that identify a peer.  Properties that may carry
+// secrets or diagnostics, such as process-args, docker-args,
+// resolver-errors and kube-annotations, are left out.
+var DefaultClaims = []string{
+	"system-uid",
+	"system-gid",
+	"docker-id",
+	"docker-name",
+	"docker-image",
+	"containerd-id",
+	"containerd-namespace",
+	"containerd-image",
+	"cri-container-id",
+	"cri-container-name",
+	"cri-image",
+	"cri-pod-name",
+	"cri-pod-namespace",
+	"cri-pod-uid",
+	"podman-id",
+	"podman-name",
+	"podman-image",
+	"podman-pod-id",
+	"podman-pod-name",
+	"kube-namespace",
+	"kube-pod-name",
+	"kube-pod-uid",
+	"kube-owner",
+	"kube-container-name",
+	"kube-service-account",
+	"kube-labels",
+	"systemd-unit",
+	"systemd-slice",
+	"process-exe",
+	"process-exe-sha256",
+	"server-listener",
+}
+
+// Claims are the JSON claims of a token.
+//
+// Registered claims (iss, aud, iat, nbf, exp) are accompanied
+// by one claim per included property, keyed by property name.
+type Claims map[string]interface{}
+
+// ClaimsFromPropSet converts the named properties into claims.  Maps
+// and lists become JSON objects and arrays; times RFC 3339 strings.
+// Properties that aren't named are left out.
+func ClaimsFromPropSet(pset propset.PropSet, names []string) Claims {
+	claims := make(Claims)
+	for _, name := range names {
+		if prop, ok := pset[name]; ok {
+			claims[name] = prop.Value()
+		}
+	}
+	return claims
+}
+
+// Issuer mints signed tokens for resolved peers.
+type Issuer interface {
+	Issue(pset propset.PropSet, audience string, ttl time.Duration) (string, time.Time, error)
+	JWKS() JWKS
+}
+
+// NewIssuer returns an Issuer which signs tokens with the keys
+// from source, with claims for the named properties (DefaultClaims
+// if nil).  A zero TTL is replaced with DefaultTTL and TTLs greater
+// than maxTTL are rejected.
+func NewIssuer(name string, source KeySource, maxTTL time.Duration, claims []string) Issuer {
+	if claims == nil {
+		claims = DefaultClaims
+	}
+	return &issuer{
+		name:   name,
+		source: source,
+		maxTTL: maxTTL,
+		claims: claims,
+		now:    time.Now,
+	}
+}
+
+type issuer struct {
+	name   string
+	source KeySource
+	maxTTL time.Duration
+	claims []string
+	now    func() time.Time
+}
+
+func (i *issuer) Issue(pset propset.PropSet, audience string, ttl time.Duration) (string, time.Time, error) {
+	if audience == "" {
+		return "", time.Time{}, ErrInvalidAudience
+	}
+
+	if ttl == 0 {
+		ttl = DefaultTTL
+	}
+
+	if ttl < 0 || ttl > i.maxTTL {
+		return "", time.Time{}, ErrInvalidTTL
+	}
+
+	key, err := i.source.SigningKey()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	now := i.now()
+	exp := now.Add(ttl)
+
+	claims := ClaimsFromPropSet(pset, i.claims)
+	claims["iss"] = i.name
+	claims["aud"] = audience
+	claims["iat"] = now.Unix()
+	claims["nbf"] = now.Unix()
+	claims["exp"] = exp.Unix()
+
+	token, err := sign(key, claims)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return token, exp, nil
+}
+
+func (i *issuer) JWKS() JWKS {
+	return NewJWKS(i.source.VerificationKeys())
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+	Kid string `json:"kid"`
+}
+
+func sign(key *Key, claims Claims) (string, error) {
+	hbuf, err := json.Marshal(header{Alg: key.Algorithm(), Typ: "JWT", Kid: key.ID()})
+	if err != nil {
+		return "", err
+	}
+
+	cbuf, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	input := encodeSegment(hbuf) + "." + encodeSegment(cbuf)
+
+	sig, err := key.sign([]byte(input))
+	if err != nil {
+		return "", err
+	}
+
+	return input + "." + encodeSegment(sig), nil
+}
+
+// Verify checks the signature of token against jwks and validates
+// its audience and lifetime.  The claims are returned if valid.
+func Verify(token string, jwks JWKS, audience string, now time.Time) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformedToken
+	}
+
+	var hdr header
+	if err := decodeSegment(parts[0], &hdr); err != nil {
+		return nil, ErrMalformedToken
+	}
+
+	jwk, ok := jwks.find(hdr.Kid)
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+
+	pub, err := jwk.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+
+	if !verifySignature(pub, hdr.Alg, []byte(parts[0]+"."+parts[1]), sig) {
+		return nil, ErrInvalidSignature
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, ErrMalformedToken
+	}
+
+	if aud, _ := claims["aud"].(string); aud != audience {
+		return nil, ErrWrongAudience
+	}
+
+	exp, _ := claims["exp"].(float64)
+	nbf, _ := claims["nbf"].(float64)
+
+	if now.Unix() >= int64(exp) || now.Unix() < int64(nbf) {
+		return nil, ErrExpired
+	}
+
+	return claims, nil
+}
+
+func encodeSegment(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeSegment(seg string, obj interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, obj)
+}
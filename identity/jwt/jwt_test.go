@@ -0,0 +1,77 @@
+package jwt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/boz/circumspect/propset"
+)
+
+func TestIssueLeavesOutSensitiveProperties(t *testing.T) {
+	key, err := generateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issuer := NewIssuer("test", staticKeySource{key}, time.Hour, nil)
+
+	pset := propset.New().
+		AddInt("system-uid", 1000).
+		AddString("kube-namespace", "default").
+		AddMap("kube-labels", map[string]string{"app": "api"}).
+		AddMap("kube-annotations", map[string]string{"secret": "hunter2"}).
+		AddStrings("process-args", []string{"server", "--password=hunter2"}).
+		AddMap("resolver-errors", map[string]string{"docker": "connection refused"})
+
+	token, _, err := issuer.Issue(pset, "aud", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims, err := Verify(token, issuer.JWKS(), "aud", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"system-uid", "kube-namespace", "kube-labels"} {
+		if _, ok := claims[name]; !ok {
+			t.Errorf("claim %v missing", name)
+		}
+	}
+
+	for _, name := range []string{"kube-annotations", "process-args", "resolver-errors"} {
+		if value, ok := claims[name]; ok {
+			t.Errorf("claim %v included: %v", name, value)
+		}
+	}
+}
+
+func TestIssueWithClaims(t *testing.T) {
+	key, err := generateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issuer := NewIssuer("test", staticKeySource{key}, time.Hour, []string{"kube-namespace"})
+
+	pset := propset.New().
+		AddInt("system-uid", 1000).
+		AddString("kube-namespace", "default")
+
+	token, _, err := issuer.Issue(pset, "aud", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims, err := Verify(token, issuer.JWKS(), "aud", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if claims["kube-namespace"] != "default" {
+		t.Errorf("kube-namespace = %v, want default", claims["kube-namespace"])
+	}
+	if _, ok := claims["system-uid"]; ok {
+		t.Error("claim system-uid included")
+	}
+}
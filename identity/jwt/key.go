@@ -0,0 +1,139 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"math/big"
+)
+
+var ErrUnsupportedKey = errors.New("unsupported signing key")
+
+// Key is a private key used to sign tokens.
+type Key struct {
+	id     string
+	alg    string
+	signer crypto.Signer
+}
+
+// NewKey wraps the given EC (P-256, P-384) or RSA private key.
+// The key ID is derived from a hash of the public key.
+func NewKey(signer crypto.Signer) (*Key, error) {
+	alg, err := algorithmFor(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(der)
+
+	return &Key{
+		id:     base64.RawURLEncoding.EncodeToString(sum[:16]),
+		alg:    alg,
+		signer: signer,
+	}, nil
+}
+
+func (k *Key) ID() string {
+	return k.id
+}
+
+func (k *Key) Algorithm() string {
+	return k.alg
+}
+
+func (k *Key) Public() crypto.PublicKey {
+	return k.signer.Public()
+}
+
+func (k *Key) sign(data []byte) ([]byte, error) {
+	hash := hashFor(k.alg)
+
+	h := hash.New()
+	h.Write(data)
+	digest := h.Sum(nil)
+
+	switch priv := k.signer.(type) {
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, priv, digest)
+		if err != nil {
+			return nil, err
+		}
+
+		// JWS uses the fixed-width concatenation of r and s.
+		size := (priv.Curve.Params().BitSize + 7) / 8
+		sig := make([]byte, 2*size)
+		copyPadded(sig[:size], r.Bytes())
+		copyPadded(sig[size:], s.Bytes())
+		return sig, nil
+
+	case *rsa.PrivateKey:
+		return rsa.SignPKCS1v15(rand.Reader, priv, hash, digest)
+
+	default:
+		return nil, ErrUnsupportedKey
+	}
+}
+
+// copyPadded right-aligns src within dst.
+func copyPadded(dst []byte, src []byte) {
+	copy(dst[len(dst)-len(src):], src)
+}
+
+func algorithmFor(pub crypto.PublicKey) (string, error) {
+	switch pub := pub.(type) {
+	case *ecdsa.PublicKey:
+		switch pub.Curve {
+		case elliptic.P256():
+			return "ES256", nil
+		case elliptic.P384():
+			return "ES384", nil
+		}
+	case *rsa.PublicKey:
+		return "RS256", nil
+	}
+	return "", ErrUnsupportedKey
+}
+
+func hashFor(alg string) crypto.Hash {
+	if alg == "ES384" {
+		return crypto.SHA384
+	}
+	return crypto.SHA256
+}
+
+func verifySignature(pub crypto.PublicKey, alg string, data []byte, sig []byte) bool {
+	if palg, err := algorithmFor(pub); err != nil || palg != alg {
+		return false
+	}
+
+	hash := hashFor(alg)
+
+	h := hash.New()
+	h.Write(data)
+	digest := h.Sum(nil)
+
+	switch pub := pub.(type) {
+	case *ecdsa.PublicKey:
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(sig) != 2*size {
+			return false
+		}
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		return ecdsa.Verify(pub, digest, r, s)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(pub, hash, digest, sig) == nil
+	}
+	return false
+}
@@ -0,0 +1,161 @@
+package jwt
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"sync"
+	"time"
+
+	"github.com/boz/circumspect/identity"
+	"github.com/sirupsen/logrus"
+)
+
+var pkglog = logrus.StandardLogger().WithField("package", "identity/jwt")
+
+// KeySource provides the key used for signing new tokens
+// and the set of keys which relying parties should accept.
+type KeySource interface {
+	SigningKey() (*Key, error)
+	VerificationKeys() []*Key
+}
+
+// NewFileKeySource loads a PEM-encoded private key from path.
+func NewFileKeySource(path string) (KeySource, error) {
+	signer, err := identity.ReadSigner(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := NewKey(signer)
+	if err != nil {
+		return nil, err
+	}
+
+	return staticKeySource{key}, nil
+}
+
+type staticKeySource struct {
+	key *Key
+}
+
+func (s staticKeySource) SigningKey() (*Key, error) {
+	return s.key, nil
+}
+
+func (s staticKeySource) VerificationKeys() []*Key {
+	return []*Key{s.key}
+}
+
+// RotatingKeySource generates a new in-memory P-256 key every period.
+// The previous key remains available for verification for one more period,
+// so tokens must not be issued with a TTL longer than the rotation period.
+type RotatingKeySource interface {
+	KeySource
+	Shutdown()
+	Done() <-chan struct{}
+}
+
+func NewRotatingKeySource(ctx context.Context, period time.Duration) (RotatingKeySource, error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	key, err := generateKey()
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	s := &rotatingKeySource{
+		period:  period,
+		current: key,
+		donech:  make(chan struct{}),
+		log:     pkglog.WithField("component", "rotating-key-source"),
+		cancel:  cancel,
+		ctx:     ctx,
+	}
+
+	s.log.WithField("kid", key.ID()).Debug("key generated")
+
+	go s.run()
+
+	return s, nil
+}
+
+type rotatingKeySource struct {
+	period   time.Duration
+	current  *Key
+	previous *Key
+	mtx      sync.RWMutex
+
+	donech chan struct{}
+	log    logrus.FieldLogger
+	cancel context.CancelFunc
+	ctx    context.Context
+}
+
+func (s *rotatingKeySource) SigningKey() (*Key, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return s.current, nil
+}
+
+func (s *rotatingKeySource) VerificationKeys() []*Key {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	if s.previous == nil {
+		return []*Key{s.current}
+	}
+	return []*Key{s.current, s.previous}
+}
+
+func (s *rotatingKeySource) Shutdown() {
+	s.cancel()
+	<-s.donech
+}
+
+func (s *rotatingKeySource) Done() <-chan struct{} {
+	return s.donech
+}
+
+func (s *rotatingKeySource) run() {
+	defer close(s.donech)
+	defer s.log.Debug("done")
+
+	ticker := time.NewTicker(s.period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			s.rotate()
+		}
+	}
+}
+
+func (s *rotatingKeySource) rotate() {
+	key, err := generateKey()
+	if err != nil {
+		s.log.WithError(err).Error("error generating key")
+		return
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.previous = s.current
+	s.current = key
+
+	s.log.WithField("kid", key.ID()).Debug("key rotated")
+}
+
+func generateKey() (*Key, error) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return NewKey(priv)
+}
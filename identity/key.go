@@ -0,0 +1,58 @@
+package identity
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+)
+
+var (
+	ErrNoPEMData          = errors.New("no PEM data found")
+	ErrUnsupportedKeyType = errors.New("unsupported private key type")
+)
+
+// ReadSigner reads a PEM-encoded EC or RSA private key from path.
+func ReadSigner(path string) (crypto.Signer, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSigner(data)
+}
+
+// ParseSigner parses the first private key found in the given PEM data.
+// PKCS#1, PKCS#8 and SEC 1 encodings are supported.
+func ParseSigner(data []byte) (crypto.Signer, error) {
+	for {
+		var block *pem.Block
+
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, ErrNoPEMData
+		}
+
+		switch block.Type {
+		case "EC PRIVATE KEY":
+			return x509.ParseECPrivateKey(block.Bytes)
+		case "RSA PRIVATE KEY":
+			return x509.ParsePKCS1PrivateKey(block.Bytes)
+		case "PRIVATE KEY":
+			key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			switch key := key.(type) {
+			case *ecdsa.PrivateKey:
+				return key, nil
+			case *rsa.PrivateKey:
+				return key, nil
+			default:
+				return nil, ErrUnsupportedKeyType
+			}
+		}
+	}
+}
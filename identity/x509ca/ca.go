@@ -0,0 +1,318 @@
+package x509ca
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/boz/circumspect/identity"
+	"github.com/boz/circumspect/propset"
+)
+
+const (
+	DefaultTTL    = time.Hour
+	DefaultMaxTTL = 24 * time.Hour
+
+	DefaultURITemplate = "spiffe://cluster/ns/{kube-namespace}/sa/{kube-service-account}"
+
+	// allow for clock skew between the node and relying parties.
+	backdate = time.Minute
+)
+
+var (
+	// ContainerIDExtension holds the resolved container ID as a UTF8String.
+	// todo: move under a registered private enterprise number.
+	ContainerIDExtension = asn1.ObjectIdentifier{2, 999, 1, 1}
+
+	// Properties checked, in order, for the container ID extension.
+	containerIDProps = []string{"docker-id", "containerd-id", "cri-container-id", "podman-id"}
+
+	ErrInvalidTTL     = errors.New("invalid TTL")
+	ErrInvalidCSR     = errors.New("invalid certificate signing request")
+	ErrNoCertificate  = errors.New("no CA certificate found")
+	ErrKeyMismatch    = errors.New("CA key does not match certificate")
+	ErrCANotAuthority = errors.New("CA certificate is not a certificate authority")
+
+	templateVariable = regexp.MustCompile(`\{([a-zA-Z0-9_.-]+)\}`)
+)
+
+// Issued is the result of signing a workload certificate.
+type Issued struct {
+	// DER-encoded certificates; the workload certificate followed by the CA.
+	Certificates [][]byte
+
+	// DER-encoded SEC 1 private key.  Only set when no CSR was given.
+	PrivateKey []byte
+
+	ExpiresAt time.Time
+}
+
+// CA signs short-lived workload certificates whose SAN URI is
+// derived from the resolved properties of the workload.
+type CA interface {
+	// Issue signs a certificate for the given DER-encoded CSR.
+	// If csr is empty, a new P-256 key is generated and returned.
+	Issue(pset propset.PropSet, csr []byte, ttl time.Duration) (Issued, error)
+
+	// Bundle returns the certificates that workload certificates chain to.
+	Bundle() []*x509.Certificate
+}
+
+// NewCAFromFiles loads a PEM-encoded CA certificate and private key.
+func NewCAFromFiles(certPath, keyPath, uriTemplate string, maxTTL time.Duration) (CA, error) {
+	data, err := ioutil.ReadFile(certPath)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := parseCertificate(data)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := identity.ReadSigner(keyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCA(cert, key, uriTemplate, maxTTL)
+}
+
+func NewCA(cert *x509.Certificate, key crypto.Signer, uriTemplate string, maxTTL time.Duration) (CA, error) {
+	if !cert.IsCA {
+		return nil, ErrCANotAuthority
+	}
+
+	pub, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		return nil, err
+	}
+	if string(pub) != string(cert.RawSubjectPublicKeyInfo) {
+		return nil, ErrKeyMismatch
+	}
+
+	uri, err := ParseURITemplate(uriTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ca{
+		cert:        cert,
+		key:         key,
+		uriTemplate: uri,
+		maxTTL:      maxTTL,
+		now:         time.Now,
+	}, nil
+}
+
+type ca struct {
+	cert        *x509.Certificate
+	key         crypto.Signer
+	uriTemplate *URITemplate
+	maxTTL      time.Duration
+	now         func() time.Time
+}
+
+func (c *ca) Bundle() []*x509.Certificate {
+	return []*x509.Certificate{c.cert}
+}
+
+func (c *ca) Issue(pset propset.PropSet, csr []byte, ttl time.Duration) (Issued, error) {
+	if ttl == 0 {
+		ttl = DefaultTTL
+	}
+	if ttl < 0 || ttl > c.maxTTL {
+		return Issued{}, ErrInvalidTTL
+	}
+
+	uri, err := c.uriTemplate.Expand(pset)
+	if err != nil {
+		return Issued{}, err
+	}
+
+	var issued Issued
+	var pub crypto.PublicKey
+
+	if len(csr) > 0 {
+		req, err := x509.ParseCertificateRequest(csr)
+		if err != nil {
+			return Issued{}, ErrInvalidCSR
+		}
+		if err := req.CheckSignature(); err != nil {
+			return Issued{}, ErrInvalidCSR
+		}
+		pub = req.PublicKey
+	} else {
+		priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return Issued{}, err
+		}
+		if issued.PrivateKey, err = x509.MarshalECPrivateKey(priv); err != nil {
+			return Issued{}, err
+		}
+		pub = priv.Public()
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return Issued{}, err
+	}
+
+	now := c.now()
+
+	notAfter := now.Add(ttl)
+	if notAfter.After(c.cert.NotAfter) {
+		notAfter = c.cert.NotAfter
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		NotBefore:             now.Add(-backdate),
+		NotAfter:              notAfter,
+		URIs:                  []*url.URL{uri},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+	}
+
+	if id := containerID(pset); id != "" {
+		value, err := asn1.MarshalWithParams(id, "utf8")
+		if err != nil {
+			return Issued{}, err
+		}
+		tmpl.ExtraExtensions = append(tmpl.ExtraExtensions, pkix.Extension{
+			Id:    ContainerIDExtension,
+			Value: value,
+		})
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, c.cert, pub, c.key)
+	if err != nil {
+		return Issued{}, err
+	}
+
+	issued.Certificates = [][]byte{der, c.cert.Raw}
+	issued.ExpiresAt = notAfter
+
+	return issued, nil
+}
+
+// URITemplate builds the SAN URI of a certificate from properties.
+type URITemplate struct {
+	text string
+
+	// set if text is a propset.Template.
+	tmpl *propset.Template
+}
+
+// ParseURITemplate parses a SAN URI template.  Each `{property-name}`
+// in text is replaced with the escaped value of the named property.
+// Map entries and list items can be given by path (`{kube.labels.app}`);
+// see propset.Get.
+//
+// Text containing `{{` is instead parsed as a propset.Template, whose
+// actions are escaped in the same way; see propset.ParseURITemplate.
+func ParseURITemplate(text string) (*URITemplate, error) {
+	if strings.Contains(text, "{{") {
+		tmpl, err := propset.ParseURITemplate(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid uri template: %v", err)
+		}
+		return &URITemplate{text, tmpl}, nil
+	}
+
+	// check that the template gives a URI whatever the values.
+	sample := templateVariable.ReplaceAllString(text, "x")
+	if strings.ContainsAny(sample, "{}") {
+		return nil, fmt.Errorf("invalid uri template: unmatched brace in %q", text)
+	}
+	if uri, err := url.Parse(sample); err != nil || !uri.IsAbs() {
+		return nil, fmt.Errorf("invalid uri template: %q is not an absolute URI", text)
+	}
+
+	return &URITemplate{text: text}, nil
+}
+
+// Expand returns the URI for pset.  Every property named by a
+// `{property-name}` template must be present and non-empty.
+func (t *URITemplate) Expand(pset propset.PropSet) (*url.URL, error) {
+	if t.tmpl != nil {
+		expanded, err := t.tmpl.Render(pset)
+		if err != nil {
+			return nil, fmt.Errorf("uri template: %v", err)
+		}
+		return url.Parse(expanded)
+	}
+
+	var missing []string
+
+	expanded := templateVariable.ReplaceAllStringFunc(t.text, func(match string) string {
+		name := match[1 : len(match)-1]
+
+		prop, ok := pset.Get(name)
+		if !ok || prop.String() == "" {
+			missing = append(missing, name)
+			return ""
+		}
+
+		return url.PathEscape(prop.String())
+	})
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing properties for uri template: %v", missing)
+	}
+
+	return url.Parse(expanded)
+}
+
+// ContainerIDFromCertificate returns the value of the container ID extension, if any.
+func ContainerIDFromCertificate(cert *x509.Certificate) (string, bool) {
+	for _, ext := range cert.Extensions {
+		if !ext.Id.Equal(ContainerIDExtension) {
+			continue
+		}
+		var id string
+		if _, err := asn1.Unmarshal(ext.Value, &id); err != nil {
+			return "", false
+		}
+		return id, true
+	}
+	return "", false
+}
+
+func containerID(pset propset.PropSet) string {
+	for _, name := range containerIDProps {
+		if prop, ok := pset[name]; ok && prop.String() != "" {
+			return prop.String()
+		}
+	}
+	return ""
+}
+
+func parseCertificate(data []byte) (*x509.Certificate, error) {
+	for {
+		var block *pem.Block
+
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, ErrNoCertificate
+		}
+
+		if block.Type == "CERTIFICATE" {
+			return x509.ParseCertificate(block.Bytes)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package x509ca
+
+import (
+	"testing"
+
+	"github.com/boz/circumspect/propset"
+)
+
+func TestURITemplateEscapesValues(t *testing.T) {
+	pset := propset.New().
+		AddString("kube-namespace", "a/b").
+		AddMap("kube-labels", map[string]string{"app": "c d"})
+
+	for _, tc := range []struct {
+		text string
+		want string
+	}{
+		{"spiffe://cluster/ns/{kube-namespace}/app/{kube.labels.app}", "spiffe://cluster/ns/a%2Fb/app/c%20d"},
+		{"spiffe://cluster/ns/{{ get \"kube-namespace\" }}/app/{{ get \"kube.labels.app\" }}", "spiffe://cluster/ns/a%2Fb/app/c%20d"},
+		{"spiffe://cluster/ns/{{ get \"kube-namespace\" | uri }}", "spiffe://cluster/ns/a%2Fb"},
+	} {
+		tmpl, err := ParseURITemplate(tc.text)
+		if err != nil {
+			t.Fatalf("%v: %v", tc.text, err)
+		}
+
+		uri, err := tmpl.Expand(pset)
+		if err != nil {
+			t.Fatalf("%v: %v", tc.text, err)
+		}
+
+		if got := uri.String(); got != tc.want {
+			t.Errorf("%v: got %v, want %v", tc.text, got, tc.want)
+		}
+	}
+}
+
+func TestParseURITemplateRejectsInvalid(t *testing.T) {
+	for _, text := range []string{
+		"cluster/ns/{kube-namespace}",
+		"spiffe://cluster/ns/{kube-namespace",
+		"spiffe://cluster/ns/{{ get }",
+	} {
+		if _, err := ParseURITemplate(text); err == nil {
+			t.Errorf("%v: no error", text)
+		}
+	}
+}
@@ -2,13 +2,24 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"sync"
 	"syscall"
+	"time"
 
+	"github.com/boz/circumspect/config"
 	"github.com/boz/circumspect/discovery"
+	"github.com/boz/circumspect/identity/jwt"
+	"github.com/boz/circumspect/identity/x509ca"
+	"github.com/boz/circumspect/policy"
 	"github.com/boz/circumspect/propset"
 	"github.com/boz/circumspect/resolver/uds"
 	"github.com/boz/circumspect/rpc"
@@ -22,13 +33,13 @@ var (
 			Default("info").
 			Enum("debug", "info", "warn", "error")
 
-	flagEnableDocker = kingpin.Flag("docker", "enable docker discovery").
-				Default("true").
-				Bool()
+	flagConfig = kingpin.Flag("config", "YAML config file").
+			ExistingFile()
 
-	flagEnableKube = kingpin.Flag("kube", "enable kube discovery").
-			Default("false").
-			Bool()
+	flagResolvers = kingpin.Flag("resolver",
+		"resolvers to enable, comma separated ("+strings.Join(discovery.Plugins(), ", ")+"). "+
+			"overrides the config file (default: docker)").
+		Strings()
 
 	cmdClient        = kingpin.Command("client", "run rpc client")
 	flagClientSocket = cmdClient.Flag("socket", "rpc socket path").
@@ -37,7 +48,97 @@ var (
 				String()
 
 	cmdServer        = kingpin.Command("server", "run rpc server")
-	flagServerSocket = cmdServer.Flag("socket", "rpc socket path").
+	flagServerSocket = cmdServer.Flag("socket",
+		"socket to listen on: a path, @abstract-name, systemd[:name] or tcp://127.0.0.1:port. "+
+			"repeatable; overrides the config file (default: "+config.DefaultSocket+")").
+		Short('s').
+		Strings()
+	flagServerOutput = cmdServer.Flag("output", "format of the properties printed for each peer").
+				Short('o').
+				Default(propset.FormatTable).
+				Enum(propset.Formats...)
+
+	// the flags below override the server section of the config file.
+
+	flagServerJWTKey = cmdServer.Flag("jwt-key", "PEM private key for signing tokens (default: rotating in-memory key)").
+				String()
+	flagServerJWTKeyRotation = cmdServer.Flag("jwt-key-rotation", "rotation period of the in-memory signing key (default: 1h)").
+					Duration()
+	flagServerJWTIssuer = cmdServer.Flag("jwt-issuer", "token issuer name (default: circumspect)").
+				String()
+	flagServerJWTMaxTTL = cmdServer.Flag("jwt-max-ttl", "maximum token lifetime (default: "+jwt.DefaultMaxTTL.String()+")").
+				Duration()
+	flagServerJWKSListen = cmdServer.Flag("jwks-listen", "serve JWKS over http on this address").
+				String()
+
+	flagServerX509CACert = cmdServer.Flag("x509-ca-cert", "PEM CA certificate for signing workload certificates").
+				String()
+	flagServerX509CAKey = cmdServer.Flag("x509-ca-key", "PEM CA private key for signing workload certificates").
+				String()
+	flagServerX509URITemplate = cmdServer.Flag("x509-uri-template",
+		"SAN URI template for workload certificates (default: "+x509ca.DefaultURITemplate+")").
+		String()
+	flagServerX509MaxTTL = cmdServer.Flag("x509-max-ttl", "maximum certificate lifetime (default: "+x509ca.DefaultMaxTTL.String()+")").
+				Duration()
+
+	flagServerPolicy = cmdServer.Flag("policy", "authorization policy file").
+				ExistingFile()
+
+	flagServerChangeEvents = cmdServer.Flag("change-events", "append a JSON change event to this file (- for stdout) "+
+		"each time the properties of a watching peer change").
+		String()
+
+	cmdWatch        = kingpin.Command("watch", "stream identity updates")
+	flagWatchSocket = cmdWatch.Flag("socket", "rpc socket path").
+			Short('s').
+			Default("/tmp/circumspect.sock").
+			String()
+	flagWatchJWTAudience = cmdWatch.Flag("jwt-audience", "include a token for this audience").
+				String()
+	flagWatchJWTTTL = cmdWatch.Flag("jwt-ttl", "token lifetime").
+			Default(jwt.DefaultTTL.String()).
+			Duration()
+	flagWatchX509 = cmdWatch.Flag("x509", "include a workload certificate").
+			Bool()
+	flagWatchX509TTL = cmdWatch.Flag("x509-ttl", "certificate lifetime").
+				Default(x509ca.DefaultTTL.String()).
+				Duration()
+
+	cmdJWT        = kingpin.Command("jwt", "fetch a signed identity token")
+	flagJWTSocket = cmdJWT.Flag("socket", "rpc socket path").
+			Short('s').
+			Default("/tmp/circumspect.sock").
+			String()
+	flagJWTAudience = cmdJWT.Flag("audience", "token audience").
+			Required().
+			String()
+	flagJWTTTL = cmdJWT.Flag("ttl", "token lifetime").
+			Default(jwt.DefaultTTL.String()).
+			Duration()
+
+	cmdJWKS        = kingpin.Command("jwks", "fetch token verification keys")
+	flagJWKSSocket = cmdJWKS.Flag("socket", "rpc socket path").
+			Short('s').
+			Default("/tmp/circumspect.sock").
+			String()
+
+	cmdX509        = kingpin.Command("x509", "fetch a workload certificate")
+	flagX509Socket = cmdX509.Flag("socket", "rpc socket path").
+			Short('s').
+			Default("/tmp/circumspect.sock").
+			String()
+	flagX509TTL = cmdX509.Flag("ttl", "certificate lifetime").
+			Default(x509ca.DefaultTTL.String()).
+			Duration()
+	flagX509CertFile = cmdX509.Flag("cert-file", "write certificate chain to this file").
+				Default("svid.pem").
+				String()
+	flagX509KeyFile = cmdX509.Flag("key-file", "write private key to this file").
+			Default("svid.key").
+			String()
+
+	cmdX509Bundle        = kingpin.Command("x509-bundle", "fetch the CA trust bundle")
+	flagX509BundleSocket = cmdX509Bundle.Flag("socket", "rpc socket path").
 				Short('s').
 				Default("/tmp/circumspect.sock").
 				String()
@@ -45,8 +146,33 @@ var (
 	cmdPid   = kingpin.Command("pid", "inspect given pid(s)")
 	flagPids = cmdPid.Arg("pid", "pid to inspect").
 			Ints()
+	flagPidOutput = cmdPid.Flag("output", "output format").
+			Short('o').
+			Default(propset.FormatTable).
+			Enum(propset.Formats...)
+	flagPidWhere = cmdPid.Flag("where", "only print processes matching this selector (e.g. 'kube-namespace == kube-system')").
+			String()
+	flagPidSelect = cmdPid.Flag("select", "only print these properties, comma separated (e.g. docker-id,kube.labels.app)").
+			Strings()
+	flagPidFormat = cmdPid.Flag("format", "print each process with this template instead of --output (e.g. '{{ .kube.namespace }}/{{ .kube.pod }}')").
+			String()
+
+	cmdConfig     = kingpin.Command("config", "configuration tools")
+	cmdConfigDump = cmdConfig.Command("dump", "print the effective configuration")
+
+	cmdPolicy         = kingpin.Command("policy", "authorization policy tools")
+	cmdPolicyTest     = cmdPolicy.Command("test", "evaluate a policy against a pid")
+	flagPolicyTestPid = cmdPolicyTest.Flag("pid", "pid to evaluate").
+				Required().
+				Int()
+	flagPolicyTestFile = cmdPolicyTest.Flag("policy", "policy file").
+				Required().
+				ExistingFile()
 )
 
+// exitStatus is returned after all resources have been released.
+var exitStatus = 0
+
 func main() {
 
 	kingpin.CommandLine.HelpFlag.Short('h')
@@ -56,27 +182,62 @@ func main() {
 
 	configureLogger()
 
+	defer func() {
+		if exitStatus != 0 {
+			os.Exit(exitStatus)
+		}
+	}()
+
 	var wg sync.WaitGroup
 	defer wg.Wait()
 
 	ctx, cancel := context.WithCancel(context.Background())
 	watchSignals(ctx, cancel, &wg)
 
-	if command == "client" {
+	switch command {
+	case "client":
 		defer cancel()
 		runClient(ctx)
 		return
+	case "watch":
+		defer cancel()
+		runWatch(ctx)
+		return
+	case "jwt":
+		defer cancel()
+		runJWT(ctx)
+		return
+	case "jwks":
+		defer cancel()
+		runJWKS(ctx)
+		return
+	case "x509":
+		defer cancel()
+		runX509(ctx)
+		return
+	case "x509-bundle":
+		defer cancel()
+		runX509Bundle(ctx)
+		return
+	case "config dump":
+		defer cancel()
+		runConfigDump()
+		return
 	}
 
-	rset := openResolver(ctx)
+	cfg := loadConfig()
+
+	rset := openResolver(ctx, cfg)
 	defer rset.Shutdown()
 	defer cancel()
 
 	switch command {
 	case "server":
-		runServer(ctx, rset)
+		runServer(ctx, cfg, rset)
 	case "pid":
 		runPid(ctx, rset)
+	case "policy test":
+		runPolicyTest(ctx, rset)
 	}
 
 }
@@ -104,24 +265,292 @@ func watchSignals(
 	}()
 }
 
-func openResolver(ctx context.Context) discovery.Strategy {
-	discovery, err := discovery.Build(ctx, *flagEnableDocker, *flagEnableKube)
+// loadConfig returns the configuration from the config file, if
+// given, with command line overrides applied.
+func loadConfig() config.Config {
+	cfg := config.Default()
+
+	if *flagConfig != "" {
+		var err error
+		cfg, err = config.Load(*flagConfig)
+		kingpin.FatalIfError(err, "error loading config")
+	}
+
+	if names := splitList(*flagResolvers); len(names) > 0 {
+		cfg.Resolvers = names
+	}
+
+	overrideServerConfig(&cfg.Server)
+
+	kingpin.FatalIfError(cfg.Validate(), "invalid config")
+
+	return cfg
+}
+
+// splitList returns the comma separated items of repeated flag values.
+func splitList(values []string) []string {
+	var items []string
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+	return items
+}
+
+func openResolver(ctx context.Context, cfg config.Config) discovery.Strategy {
+	discovery, err := discovery.Build(ctx, cfg)
 	kingpin.FatalIfError(err, "error opening discovery")
 	return discovery
 }
 
+func runConfigDump() {
+	buf, err := loadConfig().Marshal()
+	kingpin.FatalIfError(err, "error encoding config")
+	os.Stdout.Write(buf)
+}
+
 func runClient(ctx context.Context) {
-	rpc.RunClient(ctx, *flagClientSocket)
+	pset, err := rpc.RunClient(ctx, *flagClientSocket)
+	kingpin.FatalIfError(err, "error registering")
+	propset.Fprint(os.Stdout, pset)
 }
 
-func runServer(ctx context.Context, rset discovery.Strategy) {
-	rpc.RunServer(ctx, *flagServerSocket, func(ctx context.Context, props uds.Props) {
-		pset, err := rset.Lookup(ctx, props)
-		displayProps(props, pset, err)
+func runWatch(ctx context.Context) {
+	req := &rpc.WatchRequest{
+		JwtAudience: *flagWatchJWTAudience,
+		JwtTtl:      int64(*flagWatchJWTTTL / time.Second),
+		X509:        *flagWatchX509,
+		X509Ttl:     int64(*flagWatchX509TTL / time.Second),
+	}
+
+	err := rpc.RunWatchClient(ctx, *flagWatchSocket, req, func(resp *rpc.WatchResponse) {
+		fmt.Printf("\nupdate received:\n\n")
+		propset.Fprint(os.Stdout, resp.GetProps().PropSet())
+
+		if token := resp.GetJwt().GetToken(); token != "" {
+			fmt.Printf("\njwt: %v\n", token)
+		}
+
+		if x509 := resp.GetX509(); x509 != nil {
+			fmt.Printf("\nx509 expires: %v\n", time.Unix(x509.GetExpiresAt(), 0))
+		}
 	})
+	kingpin.FatalIfError(err, "error watching")
+}
+
+func runJWT(ctx context.Context) {
+	token, _, err := rpc.FetchJWT(ctx, *flagJWTSocket, *flagJWTAudience, *flagJWTTTL)
+	kingpin.FatalIfError(err, "error fetching jwt")
+	fmt.Println(token)
+}
+
+func runJWKS(ctx context.Context) {
+	jwks, err := rpc.FetchJWKS(ctx, *flagJWKSSocket)
+	kingpin.FatalIfError(err, "error fetching jwks")
+	buf, err := json.MarshalIndent(jwks, "", "  ")
+	kingpin.FatalIfError(err, "error encoding jwks")
+	fmt.Println(string(buf))
+}
+
+func runX509(ctx context.Context) {
+	issued, err := rpc.FetchX509(ctx, *flagX509Socket, nil, *flagX509TTL)
+	kingpin.FatalIfError(err, "error fetching certificate")
+
+	var certs []byte
+	for _, der := range issued.Certificates {
+		certs = append(certs, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})...)
+	}
+	key := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: issued.PrivateKey})
+
+	kingpin.FatalIfError(ioutil.WriteFile(*flagX509CertFile, certs, 0644), "error writing certificate")
+	kingpin.FatalIfError(ioutil.WriteFile(*flagX509KeyFile, key, 0600), "error writing key")
+}
+
+func runX509Bundle(ctx context.Context) {
+	certs, err := rpc.FetchX509Bundle(ctx, *flagX509BundleSocket)
+	kingpin.FatalIfError(err, "error fetching bundle")
+	for _, cert := range certs {
+		pem.Encode(os.Stdout, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	}
+}
+
+func runServer(ctx context.Context, cfg config.Config, rset discovery.Strategy) {
+	// don't accept requests until resolvers have loaded their initial state.
+	select {
+	case <-ctx.Done():
+		return
+	case <-rset.Ready():
+	}
+
+	out := newOutput(*flagServerOutput)
+
+	err := rpc.RunServer(ctx, serverListeners(cfg), func(ctx context.Context, props uds.Props) (propset.PropSet, error) {
+		pset, err := rset.Lookup(ctx, props)
+		displayProps(out, props, pset, err)
+
+		// a partial PropSet could satisfy a policy that the complete one
+		// would not (e.g. one requiring docker-id to be absent); peers
+		// are only identified when every resolver reached a definite status.
+		if err != nil {
+			return nil, err
+		}
+
+		return pset, nil
+	}, serverOptions(ctx, cfg.Server, rset)...)
+	kingpin.FatalIfError(err, "error running server")
+}
+
+// serverListeners returns the sockets given with --socket, or those
+// of the config file.
+func serverListeners(cfg config.Config) []rpc.Listener {
+	var listeners []rpc.Listener
+
+	if len(*flagServerSocket) > 0 {
+		for _, addr := range *flagServerSocket {
+			listeners = append(listeners, rpc.Listener{Address: addr})
+		}
+		return listeners
+	}
+
+	for _, l := range cfg.Listeners {
+		listener, err := l.Listener()
+		kingpin.FatalIfError(err, "error loading listener %v", l.Address)
+		listeners = append(listeners, listener)
+	}
+
+	return listeners
+}
+
+// overrideServerConfig applies the server flags that were given to cfg.
+func overrideServerConfig(cfg *config.Server) {
+	overrideString(&cfg.Policy, *flagServerPolicy)
+	overrideString(&cfg.ChangeEvents, *flagServerChangeEvents)
+
+	overrideString(&cfg.JWT.Issuer, *flagServerJWTIssuer)
+	overrideString(&cfg.JWT.Key, *flagServerJWTKey)
+	overrideDuration(&cfg.JWT.KeyRotation, *flagServerJWTKeyRotation)
+	overrideDuration(&cfg.JWT.MaxTTL, *flagServerJWTMaxTTL)
+	overrideString(&cfg.JWT.JWKSListen, *flagServerJWKSListen)
+
+	overrideString(&cfg.X509.CACert, *flagServerX509CACert)
+	overrideString(&cfg.X509.CAKey, *flagServerX509CAKey)
+	overrideString(&cfg.X509.URITemplate, *flagServerX509URITemplate)
+	overrideDuration(&cfg.X509.MaxTTL, *flagServerX509MaxTTL)
+}
+
+func overrideString(value *string, flag string) {
+	if flag != "" {
+		*value = flag
+	}
+}
+
+func overrideDuration(value *time.Duration, flag time.Duration) {
+	if flag != 0 {
+		*value = flag
+	}
+}
+
+func serverOptions(ctx context.Context, cfg config.Server, rset discovery.Strategy) []rpc.ServerOption {
+	keys := openJWTKeySource(ctx, cfg.JWT)
+
+	opts := []rpc.ServerOption{
+		rpc.WithCheck(discovery.CheckComplete),
+		rpc.WithJWTIssuer(jwt.NewIssuer(cfg.JWT.Issuer, keys, cfg.JWT.MaxTTL, cfg.JWT.Claims)),
+		rpc.WithWatchHandler(func(ctx context.Context, props uds.Props) <-chan propset.PropSet {
+			return rset.Watch(ctx, props)
+		}),
+	}
+
+	if cfg.JWT.JWKSListen != "" {
+		// listen before serving so that a bad address fails startup.
+		sock, err := net.Listen("tcp", cfg.JWT.JWKSListen)
+		kingpin.FatalIfError(err, "error listening for jwks")
+
+		srv := &http.Server{Handler: jwt.NewJWKSHandler(keys)}
+		go func() {
+			<-ctx.Done()
+			srv.Close()
+		}()
+		go srv.Serve(sock)
+	}
+
+	if cfg.Policy != "" {
+		p, err := policy.Load(cfg.Policy)
+		kingpin.FatalIfError(err, "error loading policy")
+		opts = append(opts, rpc.WithPolicy(p))
+	}
+
+	if cfg.ChangeEvents != "" {
+		opts = append(opts, rpc.WithChangeHandler(openChangeEvents(cfg.ChangeEvents)))
+	}
+
+	if cfg.X509.CACert != "" {
+		ca, err := x509ca.NewCAFromFiles(
+			cfg.X509.CACert, cfg.X509.CAKey,
+			cfg.X509.URITemplate, cfg.X509.MaxTTL)
+		kingpin.FatalIfError(err, "error loading x509 ca")
+		opts = append(opts, rpc.WithX509CA(ca))
+	}
+
+	return opts
+}
+
+// openChangeEvents returns a handler writing change events to path,
+// one JSON object per line.
+func openChangeEvents(path string) rpc.ChangeHandler {
+	out := os.Stdout
+	if path != "-" {
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+		kingpin.FatalIfError(err, "error opening change events file")
+		out = file
+	}
+
+	enc := json.NewEncoder(out)
+	enc.SetEscapeHTML(false)
+
+	return func(ev rpc.ChangeEvent) {
+		printMtx.Lock()
+		defer printMtx.Unlock()
+
+		if err := enc.Encode(ev); err != nil {
+			logrus.WithError(err).Error("error writing change event")
+		}
+	}
+}
+
+func openJWTKeySource(ctx context.Context, cfg config.JWT) jwt.KeySource {
+	if cfg.Key != "" {
+		keys, err := jwt.NewFileKeySource(cfg.Key)
+		kingpin.FatalIfError(err, "error loading jwt key")
+		return keys
+	}
+
+	// config.JWT.Validate checks that max-ttl doesn't exceed key-rotation.
+	keys, err := jwt.NewRotatingKeySource(ctx, cfg.KeyRotation)
+	kingpin.FatalIfError(err, "error creating jwt key")
+	return keys
 }
 
 func runPid(ctx context.Context, rset discovery.Strategy) {
+	out := newOutput(*flagPidOutput)
+	if *flagPidFormat != "" {
+		tmpl, err := propset.ParseTemplate(*flagPidFormat)
+		kingpin.FatalIfError(err, "invalid format")
+		out = propset.NewTemplateEncoder(os.Stdout, tmpl)
+	}
+
+	var where propset.Selector
+	if *flagPidWhere != "" {
+		var err error
+		where, err = propset.ParseSelector(*flagPidWhere)
+		kingpin.FatalIfError(err, "invalid selector")
+	}
+
+	paths := splitList(*flagPidSelect)
+
 	var wg sync.WaitGroup
 
 	wg.Add(len(*flagPids))
@@ -131,20 +560,64 @@ func runPid(ctx context.Context, rset discovery.Strategy) {
 			defer wg.Done()
 			props := uds.NewPidProps(pid)
 			pset, err := rset.Lookup(ctx, props)
-			displayProps(props, pset, err)
+
+			if err != nil {
+				printMtx.Lock()
+				exitStatus = 1
+				printMtx.Unlock()
+			}
+
+			if where != nil && !where.Matches(pset) {
+				// properties missing from a failed lookup may be why
+				// it didn't match; report it rather than drop it.
+				if err != nil {
+					printMtx.Lock()
+					fmt.Fprintf(os.Stderr, "pid %v: %v\n", pid, err)
+					printMtx.Unlock()
+				}
+				return
+			}
+			if len(paths) > 0 {
+				pset = pset.Select(paths...)
+			}
+
+			displayProps(out, props, pset, err)
 		}(pid)
 	}
 
 	wg.Wait()
 }
 
+func runPolicyTest(ctx context.Context, rset discovery.Strategy) {
+	p, err := policy.Load(*flagPolicyTestFile)
+	kingpin.FatalIfError(err, "error loading policy")
+
+	props := uds.NewPidProps(*flagPolicyTestPid)
+	pset, err := rset.Lookup(ctx, props)
+	displayProps(newOutput(propset.FormatTable), props, pset, err)
+
+	decision := p.EvaluateLookup(pset, err)
+	fmt.Printf("\n%v\n", decision)
+
+	if !decision.Allow {
+		exitStatus = 1
+	}
+}
+
 var printMtx = &sync.Mutex{}
 
-func displayProps(pprops uds.PidProps, pset propset.PropSet, err error) {
+// newOutput returns an encoder writing to stdout in the given format.
+func newOutput(format string) propset.Encoder {
+	enc, err := propset.NewEncoder(os.Stdout, format)
+	kingpin.FatalIfError(err, "invalid output format")
+	return enc
+}
+
+func displayProps(out propset.Encoder, pprops uds.PidProps, pset propset.PropSet, err error) {
 	printMtx.Lock()
 	defer printMtx.Unlock()
 
-	fmt.Printf("\nprocess %v properties:\n\n", pprops.Pid())
-	propset.Fprint(os.Stdout, pset)
-
+	if err := out.Encode(propset.NewRecord(pprops.Pid(), pset, err)); err != nil {
+		logrus.WithError(err).Error("error writing properties")
+	}
 }
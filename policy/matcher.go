@@ -0,0 +1,87 @@
+package policy
+
+import (
+	"errors"
+
+	"github.com/boz/circumspect/propset"
+)
+
+// Values matches if a value equals any of its members.
+// It is written in YAML as either a scalar or a list of scalars.
+type Values []string
+
+func (v *Values) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var list []string
+	if err := unmarshal(&list); err == nil {
+		*v = list
+		return nil
+	}
+
+	var value string
+	if err := unmarshal(&value); err != nil {
+		return errors.New("expected a scalar or a list of scalars")
+	}
+
+	*v = Values{value}
+	return nil
+}
+
+func (v Values) matches(value string) bool {
+	for _, item := range v {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Matcher matches a single property.
+//
+// Scalar properties (strings, ints, bools, times) are matched against
+// Values, and lists match if any of their items do.  Map properties are
+// matched against Entries: every entry key must be present in the map
+// with a matching value.
+type Matcher struct {
+	Values  Values
+	Entries map[string]Values
+}
+
+func (m *Matcher) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var entries map[string]Values
+	if err := unmarshal(&entries); err == nil {
+		m.Entries = entries
+		return nil
+	}
+
+	return unmarshal(&m.Values)
+}
+
+func (m Matcher) matches(prop propset.Property) bool {
+	switch prop := prop.(type) {
+	case propset.Map:
+		if m.Entries == nil {
+			return false
+		}
+		for key, values := range m.Entries {
+			value, ok := prop[key]
+			if !ok || !values.matches(value.String()) {
+				return false
+			}
+		}
+		return true
+
+	case propset.List:
+		for _, item := range prop {
+			if m.matches(item) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if m.Entries != nil {
+		return false
+	}
+
+	return m.Values.matches(prop.String())
+}
@@ -0,0 +1,178 @@
+package policy
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/boz/circumspect/propset"
+	yaml "gopkg.in/yaml.v2"
+)
+
+type Action string
+
+const (
+	ActionAllow Action = "allow"
+	ActionDeny  Action = "deny"
+)
+
+// Policy is an ordered list of rules.  The action of the first rule
+// that matches a PropSet is taken.  If no rule matches, the default
+// action (deny, unless specified) is taken.
+//
+//	default: deny
+//	rules:
+//	  - name: payments-api
+//	    match:
+//	      kube-namespace: payments
+//	      kube-labels:
+//	        app: api
+//	  - name: root-on-host
+//	    match:
+//	      system-uid: 0
+//	      system-container: false
+type Policy struct {
+	Default Action `yaml:"default"`
+	Rules   []Rule `yaml:"rules"`
+}
+
+// Rule matches a PropSet if every property in Match matches and
+// every property in Absent is missing.  Properties are named by
+// path (kube.labels.app); see propset.Get.
+type Rule struct {
+	Name   string             `yaml:"name"`
+	Action Action             `yaml:"action"`
+	Match  map[string]Matcher `yaml:"match"`
+	Absent []string           `yaml:"absent"`
+}
+
+// Decision is the result of evaluating a policy.
+type Decision struct {
+	Allow bool
+
+	// Name of the rule that matched; empty if the default action was taken.
+	Rule string
+
+	// Err is why the policy was not evaluated, if it was not.
+	Err error
+}
+
+func (d Decision) String() string {
+	action := ActionDeny
+	if d.Allow {
+		action = ActionAllow
+	}
+	if d.Err != nil {
+		return fmt.Sprintf("%v: %v", action, d.Err)
+	}
+	if d.Rule == "" {
+		return fmt.Sprintf("%v: no rule matched", action)
+	}
+	return fmt.Sprintf("%v: rule %q matched", action, d.Rule)
+}
+
+// Load reads and validates a YAML policy file.
+func Load(path string) (*Policy, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", path, err)
+	}
+
+	return p, nil
+}
+
+// Parse decodes and validates a YAML policy.
+func Parse(data []byte) (*Policy, error) {
+	p := &Policy{}
+
+	if err := yaml.UnmarshalStrict(data, p); err != nil {
+		return nil, err
+	}
+
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (p *Policy) validate() error {
+	switch p.Default {
+	case "":
+		p.Default = ActionDeny
+	case ActionAllow, ActionDeny:
+	default:
+		return fmt.Errorf("invalid default action %q", p.Default)
+	}
+
+	names := make(map[string]bool)
+
+	for idx := range p.Rules {
+		rule := &p.Rules[idx]
+
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("rules[%v]", idx)
+		}
+
+		if names[rule.Name] {
+			return fmt.Errorf("duplicate rule name %q", rule.Name)
+		}
+		names[rule.Name] = true
+
+		switch rule.Action {
+		case "":
+			rule.Action = ActionAllow
+		case ActionAllow, ActionDeny:
+		default:
+			return fmt.Errorf("rule %q: invalid action %q", rule.Name, rule.Action)
+		}
+
+		if len(rule.Match) == 0 && len(rule.Absent) == 0 {
+			return fmt.Errorf("rule %q: no match criteria", rule.Name)
+		}
+	}
+
+	return nil
+}
+
+// Evaluate returns the decision for the given PropSet.
+func (p *Policy) Evaluate(pset propset.PropSet) Decision {
+	for _, rule := range p.Rules {
+		if rule.Matches(pset) {
+			return Decision{Allow: rule.Action == ActionAllow, Rule: rule.Name}
+		}
+	}
+	return Decision{Allow: p.Default == ActionAllow}
+}
+
+// EvaluateLookup returns the decision for the result of a lookup.  As
+// the server does, it denies without evaluating the policy if the
+// lookup failed or was incomplete, since a partial PropSet may match
+// rules the complete one would not.
+func (p *Policy) EvaluateLookup(pset propset.PropSet, err error) Decision {
+	if err != nil {
+		return Decision{Err: err}
+	}
+	return p.Evaluate(pset)
+}
+
+func (r Rule) Matches(pset propset.PropSet) bool {
+	for _, name := range r.Absent {
+		if _, ok := pset.Get(name); ok {
+			return false
+		}
+	}
+
+	for name, m := range r.Match {
+		prop, ok := pset.Get(name)
+		if !ok || !m.matches(prop) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,48 @@
+package policy_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/boz/circumspect/discovery"
+	"github.com/boz/circumspect/policy"
+	"github.com/boz/circumspect/propset"
+)
+
+const hostPolicy = `
+rules:
+  - name: host
+    match:
+      system-uid: 1000
+    absent: [docker-id]
+`
+
+func TestEvaluateLookupDeniesIncompleteLookup(t *testing.T) {
+	p, err := policy.Parse([]byte(hostPolicy))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// docker timed out, so docker-id is missing whether or not the
+	// process is in a container.
+	pset := propset.New().AddInt("system-uid", 1000)
+	lerr := &discovery.LookupError{Statuses: []discovery.ResolverStatus{
+		{Name: "docker", Status: discovery.StatusTimedOut, Err: context.DeadlineExceeded},
+	}}
+
+	if decision := p.Evaluate(pset); !decision.Allow {
+		t.Fatalf("partial propset not allowed: %v", decision)
+	}
+
+	decision := p.EvaluateLookup(pset, lerr)
+	if decision.Allow {
+		t.Fatalf("incomplete lookup allowed: %v", decision)
+	}
+	if decision.Err != lerr {
+		t.Errorf("decision error: %v", decision.Err)
+	}
+
+	if decision := p.EvaluateLookup(pset, nil); !decision.Allow || decision.Rule != "host" {
+		t.Errorf("complete lookup: %v", decision)
+	}
+}
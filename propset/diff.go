@@ -0,0 +1,140 @@
+package propset
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Kinds of Change.
+const (
+	ChangeAdded   = "added"
+	ChangeRemoved = "removed"
+	ChangeChanged = "changed"
+)
+
+// Change is a difference between two PropSets at Path, which can be
+// given to PropSet.Get.  Old is nil if the value was added and New is
+// nil if it was removed.
+type Change struct {
+	Path string
+	Old  Property
+	New  Property
+}
+
+// Kind returns ChangeAdded, ChangeRemoved or ChangeChanged.
+func (c Change) Kind() string {
+	switch {
+	case c.Old == nil:
+		return ChangeAdded
+	case c.New == nil:
+		return ChangeRemoved
+	default:
+		return ChangeChanged
+	}
+}
+
+func (c Change) String() string {
+	switch c.Kind() {
+	case ChangeAdded:
+		return fmt.Sprintf("+%v=%v", c.Path, c.New)
+	case ChangeRemoved:
+		return fmt.Sprintf("-%v=%v", c.Path, c.Old)
+	default:
+		return fmt.Sprintf("%v: %v -> %v", c.Path, c.Old, c.New)
+	}
+}
+
+// MarshalJSON encodes the change as an object with its path, kind,
+// and old and new values.
+func (c Change) MarshalJSON() ([]byte, error) {
+	obj := struct {
+		Path string      `json:"path"`
+		Kind string      `json:"kind"`
+		Old  interface{} `json:"old,omitempty"`
+		New  interface{} `json:"new,omitempty"`
+	}{Path: c.Path, Kind: c.Kind()}
+
+	if c.Old != nil {
+		obj.Old = c.Old.Value()
+	}
+	if c.New != nil {
+		obj.New = c.New.Value()
+	}
+
+	return json.Marshal(obj)
+}
+
+// Changes is a list of changes sorted by path.
+type Changes []Change
+
+func (cs Changes) String() string {
+	var buf bytes.Buffer
+	for idx, c := range cs {
+		if idx > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(c.String())
+	}
+	return buf.String()
+}
+
+// Diff returns the changes from a to b.  Maps are compared entry by
+// entry, so a changed label is reported as docker-labels.app; other
+// values, including lists, are compared whole.
+func Diff(a, b PropSet) Changes {
+	var changes Changes
+
+	for name, old := range a {
+		changes = diffProperty(changes, name, old, b[name])
+	}
+
+	for name, prop := range b {
+		if _, ok := a[name]; !ok {
+			changes = append(changes, Change{Path: name, New: prop})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+
+	return changes
+}
+
+func diffProperty(changes Changes, path string, old, prop Property) Changes {
+	if prop == nil {
+		return append(changes, Change{Path: path, Old: old})
+	}
+
+	oldMap, oldOk := old.(Map)
+	newMap, newOk := prop.(Map)
+
+	if !oldOk || !newOk {
+		if !equal(old, prop) {
+			changes = append(changes, Change{Path: path, Old: old, New: prop})
+		}
+		return changes
+	}
+
+	for k, v := range oldMap {
+		changes = diffProperty(changes, path+"."+k, v, newMap[k])
+	}
+
+	for k, v := range newMap {
+		if _, ok := oldMap[k]; !ok {
+			changes = append(changes, Change{Path: path + "." + k, New: v})
+		}
+	}
+
+	return changes
+}
+
+// equal compares properties by type and value, so that times in
+// different locations are equal.
+func equal(a, b Property) bool {
+	return reflect.TypeOf(a) == reflect.TypeOf(b) &&
+		reflect.DeepEqual(a.Value(), b.Value())
+}
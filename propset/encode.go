@@ -0,0 +1,126 @@
+package propset
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Output formats for NewEncoder.
+const (
+	FormatTable  = "table"
+	FormatJSON   = "json"
+	FormatYAML   = "yaml"
+	FormatNDJSON = "ndjson"
+)
+
+// Formats lists the supported output formats.
+var Formats = []string{FormatTable, FormatJSON, FormatYAML, FormatNDJSON}
+
+// Record is the PropSet of a process along with the error, if any,
+// that kept it from being completely resolved.
+type Record struct {
+	Pid   int     `json:"pid" yaml:"pid"`
+	Props PropSet `json:"properties" yaml:"properties"`
+	Error string  `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// NewRecord returns the record of pid's properties.
+func NewRecord(pid int, pset PropSet, err error) Record {
+	r := Record{Pid: pid, Props: pset}
+	if err != nil {
+		r.Error = err.Error()
+	}
+	return r
+}
+
+// Encoder writes a stream of records.
+type Encoder interface {
+	Encode(Record) error
+}
+
+// NewEncoder returns an encoder writing records to out in the given format:
+//
+//	table   a header per process followed by the output of Fprint
+//	json    an indented JSON object per record
+//	yaml    a YAML document per record
+//	ndjson  a JSON object per record, one per line
+//
+// Properties are sorted by name and maps are encoded as nested objects.
+func NewEncoder(out io.Writer, format string) (Encoder, error) {
+	switch format {
+	case FormatTable:
+		return &tableEncoder{out}, nil
+	case FormatJSON:
+		enc := json.NewEncoder(out)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "  ")
+		return &jsonEncoder{enc}, nil
+	case FormatYAML:
+		return &yamlEncoder{out: out}, nil
+	case FormatNDJSON:
+		enc := json.NewEncoder(out)
+		enc.SetEscapeHTML(false)
+		return &jsonEncoder{enc}, nil
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+}
+
+// MarshalJSON encodes the properties as an object.  encoding/json
+// sorts the keys.  Times are encoded as RFC 3339 strings.
+func (ps PropSet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ps.Values())
+}
+
+// MarshalYAML encodes the properties as a mapping.  yaml sorts the keys.
+func (ps PropSet) MarshalYAML() (interface{}, error) {
+	return ps.Values(), nil
+}
+
+type tableEncoder struct {
+	out io.Writer
+}
+
+func (e *tableEncoder) Encode(r Record) error {
+	fmt.Fprintf(e.out, "\nprocess %v properties:\n\n", r.Pid)
+	Fprint(e.out, r.Props)
+
+	if r.Error != "" {
+		fmt.Fprintf(e.out, "\nincomplete lookup: %v\n", r.Error)
+	}
+
+	return nil
+}
+
+type jsonEncoder struct {
+	enc *json.Encoder
+}
+
+func (e *jsonEncoder) Encode(r Record) error {
+	return e.enc.Encode(r)
+}
+
+type yamlEncoder struct {
+	out     io.Writer
+	started bool
+}
+
+func (e *yamlEncoder) Encode(r Record) error {
+	buf, err := yaml.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	if e.started {
+		if _, err := io.WriteString(e.out, "---\n"); err != nil {
+			return err
+		}
+	}
+	e.started = true
+
+	_, err = e.out.Write(buf)
+	return err
+}
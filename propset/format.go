@@ -31,15 +31,27 @@ func Fprint(out io.Writer, pset PropSet) {
 				continue
 			}
 
-			first := true
-			for k, v := range prop {
-				if first {
-					fmt.Fprintf(table, "%v\t%v\n", k, v)
-					first = false
-					continue
+			for idx, k := range prop.Keys() {
+				if idx > 0 {
+					fmt.Fprintf(table, "\t")
 				}
-				fmt.Fprintf(table, "\t%v\t%v\n", k, v)
+				fmt.Fprintf(table, "%v\t%v\n", k, prop[k])
 			}
+
+		case List:
+
+			if len(prop) == 0 {
+				fmt.Fprintf(table, "[]\n")
+				continue
+			}
+
+			for idx, item := range prop {
+				if idx > 0 {
+					fmt.Fprintf(table, "\t")
+				}
+				fmt.Fprintf(table, "%v\n", item)
+			}
+
 		default:
 			fmt.Fprintf(table, "%v\n", prop)
 		}
@@ -0,0 +1,126 @@
+package propset
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Get returns the value at path.  A path begins with a property name,
+// in which '.' may be used in place of '-', and continues with the keys
+// of maps and the indexes of lists:
+//
+//	docker-labels      the docker-labels map
+//	docker.labels.app  its "app" entry
+//	process-args.0     the first item of process-args
+//
+// Map keys containing '.' (io.kubernetes.pod.name) are matched whole,
+// the longest first.
+func (ps PropSet) Get(path string) (Property, bool) {
+	segs := strings.Split(path, ".")
+
+	for i := len(segs); i > 0; i-- {
+		prop, ok := ps[strings.Join(segs[:i], "-")]
+		if !ok {
+			continue
+		}
+		if prop, ok := lookup(prop, segs[i:]); ok {
+			return prop, true
+		}
+	}
+
+	return nil, false
+}
+
+func lookup(prop Property, segs []string) (Property, bool) {
+	if len(segs) == 0 {
+		return prop, true
+	}
+
+	switch prop := prop.(type) {
+	case Map:
+		for i := len(segs); i > 0; i-- {
+			value, ok := prop[strings.Join(segs[:i], ".")]
+			if !ok {
+				continue
+			}
+			if value, ok := lookup(value, segs[i:]); ok {
+				return value, true
+			}
+		}
+	case List:
+		idx, err := strconv.Atoi(segs[0])
+		if err == nil && idx >= 0 && idx < len(prop) {
+			return lookup(prop[idx], segs[1:])
+		}
+	}
+
+	return nil, false
+}
+
+// GetString returns the string at path.  The result is false if
+// there is no value at path or if it is not a string.
+func (ps PropSet) GetString(path string) (string, bool) {
+	prop, _ := ps.Get(path)
+	if p, ok := prop.(String); ok {
+		return string(p), true
+	}
+	return "", false
+}
+
+// GetInt returns the int at path.
+func (ps PropSet) GetInt(path string) (int, bool) {
+	prop, _ := ps.Get(path)
+	if p, ok := prop.(Int); ok {
+		return int(p), true
+	}
+	return 0, false
+}
+
+// GetBool returns the bool at path.
+func (ps PropSet) GetBool(path string) (bool, bool) {
+	prop, _ := ps.Get(path)
+	if p, ok := prop.(Bool); ok {
+		return bool(p), true
+	}
+	return false, false
+}
+
+// GetTime returns the time at path.
+func (ps PropSet) GetTime(path string) (time.Time, bool) {
+	prop, _ := ps.Get(path)
+	if p, ok := prop.(Time); ok {
+		return p.Time(), true
+	}
+	return time.Time{}, false
+}
+
+// GetList returns the list at path.
+func (ps PropSet) GetList(path string) (List, bool) {
+	prop, _ := ps.Get(path)
+	if p, ok := prop.(List); ok {
+		return p, true
+	}
+	return nil, false
+}
+
+// GetMap returns the map at path.
+func (ps PropSet) GetMap(path string) (Map, bool) {
+	prop, _ := ps.Get(path)
+	if p, ok := prop.(Map); ok {
+		return p, true
+	}
+	return nil, false
+}
+
+// Select returns the values at the given paths, keyed by path.
+// Paths without a value are omitted.
+func (ps PropSet) Select(paths ...string) PropSet {
+	selected := New()
+	for _, path := range paths {
+		if prop, ok := ps.Get(path); ok {
+			selected.Add(path, prop)
+		}
+	}
+	return selected
+}
@@ -3,14 +3,28 @@ package propset
 // todo: yadda yadda yadda
 
 import (
-	"fmt"
+	"bytes"
+	"encoding/json"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 )
 
+// PropSet is a set of named properties.  Names are of the form
+// <namespace>-<name> (docker-labels), and values are typed: strings,
+// ints, bools, times, and lists and maps of these, which may nest.
+// Get looks up values by path.
 type PropSet map[string]Property
 
 type Property interface {
+	// String returns the value as text, for display and for matching
+	// by policies and templates.
 	String() string
+
+	// Value returns the value as a string, int, bool, []interface{} or
+	// map[string]interface{}, for encoding.  Times are RFC 3339 strings in UTC.
+	Value() interface{}
 }
 
 func New() PropSet {
@@ -23,7 +37,7 @@ func (ps PropSet) Add(name string, p Property) PropSet {
 }
 
 func (ps PropSet) AddMap(name string, p map[string]string) PropSet {
-	return ps.Add(name, Map(p))
+	return ps.Add(name, NewMap(p))
 }
 
 func (ps PropSet) AddString(name string, p string) PropSet {
@@ -34,6 +48,22 @@ func (ps PropSet) AddInt(name string, p int) PropSet {
 	return ps.Add(name, Int(p))
 }
 
+func (ps PropSet) AddBool(name string, p bool) PropSet {
+	return ps.Add(name, Bool(p))
+}
+
+func (ps PropSet) AddTime(name string, p time.Time) PropSet {
+	return ps.Add(name, Time(p))
+}
+
+func (ps PropSet) AddStrings(name string, p []string) PropSet {
+	return ps.Add(name, NewStrings(p))
+}
+
+func (ps PropSet) AddInts(name string, p []int) PropSet {
+	return ps.Add(name, NewInts(p))
+}
+
 func (ps PropSet) Merge(other PropSet) PropSet {
 	for k, v := range other {
 		ps.Add(k, v)
@@ -41,10 +71,116 @@ func (ps PropSet) Merge(other PropSet) PropSet {
 	return ps
 }
 
-type Map map[string]string
+// Values returns the value of each property, for encoding.
+func (ps PropSet) Values() map[string]interface{} {
+	values := make(map[string]interface{}, len(ps))
+	for name, prop := range ps {
+		values[name] = prop.Value()
+	}
+	return values
+}
+
+// Canonical returns a deterministic encoding of the properties: compact
+// JSON with the keys of every object sorted and times in UTC.
+func (ps PropSet) Canonical() []byte {
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+
+	// values are always encodable.
+	enc.Encode(ps.Values())
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+}
+
+// Map is a map property.  Its values may be of any type.
+type Map map[string]Property
+
+// NewMap returns a map of string values.
+func NewMap(m map[string]string) Map {
+	p := make(Map, len(m))
+	for k, v := range m {
+		p[k] = String(v)
+	}
+	return p
+}
+
+// Keys returns the keys of the map in sorted order.
+func (p Map) Keys() []string {
+	keys := make([]string, 0, len(p))
+	for k := range p {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Strings returns the text of each value.
+func (p Map) Strings() map[string]string {
+	m := make(map[string]string, len(p))
+	for k, v := range p {
+		m[k] = v.String()
+	}
+	return m
+}
 
 func (p Map) String() string {
-	return fmt.Sprintf("%#v", (map[string]string)(p))
+	entries := make([]string, 0, len(p))
+	for _, k := range p.Keys() {
+		entries = append(entries, k+"="+p[k].String())
+	}
+	return "{" + strings.Join(entries, ", ") + "}"
+}
+
+func (p Map) Value() interface{} {
+	m := make(map[string]interface{}, len(p))
+	for k, v := range p {
+		m[k] = v.Value()
+	}
+	return m
+}
+
+// List is a list property.  Its items may be of any type.
+type List []Property
+
+// NewStrings returns a list of string values.
+func NewStrings(items []string) List {
+	p := make(List, 0, len(items))
+	for _, item := range items {
+		p = append(p, String(item))
+	}
+	return p
+}
+
+// NewInts returns a list of int values.
+func NewInts(items []int) List {
+	p := make(List, 0, len(items))
+	for _, item := range items {
+		p = append(p, Int(item))
+	}
+	return p
+}
+
+// Strings returns the text of each item.
+func (p List) Strings() []string {
+	items := make([]string, 0, len(p))
+	for _, item := range p {
+		items = append(items, item.String())
+	}
+	return items
+}
+
+func (p List) String() string {
+	return "[" + strings.Join(p.Strings(), ", ") + "]"
+}
+
+func (p List) Value() interface{} {
+	items := make([]interface{}, 0, len(p))
+	for _, item := range p {
+		items = append(items, item.Value())
+	}
+	return items
 }
 
 type String string
@@ -53,8 +189,41 @@ func (p String) String() string {
 	return string(p)
 }
 
+func (p String) Value() interface{} {
+	return string(p)
+}
+
 type Int int
 
 func (p Int) String() string {
 	return strconv.Itoa(int(p))
 }
+
+func (p Int) Value() interface{} {
+	return int(p)
+}
+
+type Bool bool
+
+func (p Bool) String() string {
+	return strconv.FormatBool(bool(p))
+}
+
+func (p Bool) Value() interface{} {
+	return bool(p)
+}
+
+// Time is a timestamp property.  It is written in RFC 3339 format, in UTC.
+type Time time.Time
+
+func (p Time) Time() time.Time {
+	return time.Time(p)
+}
+
+func (p Time) String() string {
+	return time.Time(p).UTC().Format(time.RFC3339Nano)
+}
+
+func (p Time) Value() interface{} {
+	return p.String()
+}
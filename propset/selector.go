@@ -0,0 +1,588 @@
+package propset
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Selector tests a PropSet against an expression.
+type Selector interface {
+	Matches(PropSet) bool
+	String() string
+}
+
+// ParseSelector compiles a selector expression:
+//
+//	kube-namespace                      the property is present (and true, if a bool)
+//	kube.labels.app == "api"            equality; also !=
+//	system-uid < 1000                   ordering of ints, times and strings; also <=, >, >=
+//	docker-image in (nginx, "redis:4")  membership; also not in
+//	docker-image like "nginx:*"         glob, where * and ? match any text and character; also not like
+//	process-exe =~ "^/usr/s?bin/"       regular expression; also !~
+//
+// combined with and (&&), or (||), not (!) and parentheses.  Values are
+// quoted strings or bare words.  Properties are named by path (see Get);
+// a list matches if any of its items does (a negated test if none
+// does), and a comparison with a missing property is false.
+func ParseSelector(expr string) (Selector, error) {
+	p := &selectorParser{lex: newSelectorLexer(expr)}
+
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.tok.kind != tokEOF {
+		return nil, p.errorf("unexpected %v", p.tok)
+	}
+
+	return node, nil
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokWord
+	tokString
+	tokOp
+	tokLParen
+	tokRParen
+	tokComma
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	value string
+	pos   int
+}
+
+func (t token) String() string {
+	if t.kind == tokEOF {
+		return "end of expression"
+	}
+	return strconv.Quote(t.text)
+}
+
+// keyword returns true if the token is the given bare word.
+func (t token) keyword(word string) bool {
+	return t.kind == tokWord && t.text == word
+}
+
+var selectorOps = []string{"==", "!=", "<=", ">=", "=~", "!~", "&&", "||", "<", ">", "!"}
+
+type selectorLexer struct {
+	input string
+	pos   int
+}
+
+func newSelectorLexer(input string) *selectorLexer {
+	return &selectorLexer{input: input}
+}
+
+func isWordChar(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("-_./:*?@+", r)
+}
+
+func (l *selectorLexer) next() (token, error) {
+	for l.pos < len(l.input) && unicode.IsSpace(rune(l.input[l.pos])) {
+		l.pos++
+	}
+
+	start := l.pos
+
+	if l.pos >= len(l.input) {
+		return token{kind: tokEOF, pos: start}, nil
+	}
+
+	rest := l.input[l.pos:]
+
+	switch rest[0] {
+	case '(':
+		l.pos++
+		return token{kind: tokLParen, text: "(", pos: start}, nil
+	case ')':
+		l.pos++
+		return token{kind: tokRParen, text: ")", pos: start}, nil
+	case ',':
+		l.pos++
+		return token{kind: tokComma, text: ",", pos: start}, nil
+	case '"', '\'':
+		return l.quoted(rest[0])
+	}
+
+	for _, op := range selectorOps {
+		if strings.HasPrefix(rest, op) {
+			l.pos += len(op)
+			return token{kind: tokOp, text: op, pos: start}, nil
+		}
+	}
+
+	end := strings.IndexFunc(rest, func(r rune) bool { return !isWordChar(r) })
+	if end < 0 {
+		end = len(rest)
+	}
+	if end == 0 {
+		return token{}, fmt.Errorf("position %v: unexpected %q", start, rest[:1])
+	}
+
+	l.pos += end
+	return token{kind: tokWord, text: rest[:end], value: rest[:end], pos: start}, nil
+}
+
+// quoted scans a string quoted with q.  A backslash escapes q and itself;
+// other backslashes are kept so that regular expressions can be quoted.
+func (l *selectorLexer) quoted(q byte) (token, error) {
+	start := l.pos
+
+	var value []byte
+
+	for i := start + 1; i < len(l.input); i++ {
+		switch c := l.input[i]; {
+		case c == '\\' && i+1 < len(l.input) && (l.input[i+1] == q || l.input[i+1] == '\\'):
+			i++
+			value = append(value, l.input[i])
+		case c == q:
+			l.pos = i + 1
+			return token{kind: tokString, text: l.input[start:l.pos], value: string(value), pos: start}, nil
+		default:
+			value = append(value, c)
+		}
+	}
+
+	return token{}, fmt.Errorf("position %v: unterminated string", start)
+}
+
+type selectorParser struct {
+	lex *selectorLexer
+	tok token
+}
+
+func (p *selectorParser) next() error {
+	tok, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *selectorParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("position %v: %v", p.tok.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *selectorParser) parseOr() (Selector, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.tok.text == "||" || p.tok.keyword("or") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+
+	return left, nil
+}
+
+func (p *selectorParser) parseAnd() (Selector, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.tok.text == "&&" || p.tok.keyword("and") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+
+	return left, nil
+}
+
+func (p *selectorParser) parseUnary() (Selector, error) {
+	if (p.tok.kind == tokOp && p.tok.text == "!") || p.tok.keyword("not") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{node}, nil
+	}
+
+	if p.tok.kind == tokLParen {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokRParen {
+			return nil, p.errorf("expected ) but got %v", p.tok)
+		}
+		return node, p.next()
+	}
+
+	return p.parseTest()
+}
+
+// parseTest parses a path and the test applied to it, if any.
+func (p *selectorParser) parseTest() (Selector, error) {
+	if p.tok.kind != tokWord {
+		return nil, p.errorf("expected property but got %v", p.tok)
+	}
+
+	path := p.tok.text
+
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+
+	negate := false
+	if p.tok.keyword("not") {
+		negate = true
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		if !p.tok.keyword("in") && !p.tok.keyword("like") {
+			return nil, p.errorf("expected in or like but got %v", p.tok)
+		}
+	}
+
+	switch {
+	case p.tok.keyword("in"):
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		values, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		return testNode{path, "in", values, negate, nil}, nil
+
+	case p.tok.keyword("like"):
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		re := regexp.MustCompile(globToRegexp(value))
+		return testNode{path, "like", []string{value}, negate, re}, nil
+
+	case p.tok.kind == tokOp && p.tok.text != "!" && p.tok.text != "&&" && p.tok.text != "||":
+		op := p.tok.text
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+
+		var re *regexp.Regexp
+		if op == "=~" || op == "!~" {
+			if re, err = regexp.Compile(value); err != nil {
+				return nil, fmt.Errorf("%v: %v", path, err)
+			}
+		}
+
+		return testNode{path, op, []string{value}, false, re}, nil
+	}
+
+	return existsNode{path}, nil
+}
+
+func (p *selectorParser) parseValue() (string, error) {
+	if p.tok.kind != tokWord && p.tok.kind != tokString {
+		return "", p.errorf("expected value but got %v", p.tok)
+	}
+	value := p.tok.value
+	return value, p.next()
+}
+
+// parseList parses a parenthesized, comma separated list of values.
+func (p *selectorParser) parseList() ([]string, error) {
+	if p.tok.kind != tokLParen {
+		return nil, p.errorf("expected ( but got %v", p.tok)
+	}
+
+	var values []string
+
+	for {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+
+		switch p.tok.kind {
+		case tokComma:
+			continue
+		case tokRParen:
+			return values, p.next()
+		default:
+			return nil, p.errorf("expected , or ) but got %v", p.tok)
+		}
+	}
+}
+
+// globToRegexp returns an anchored regular expression matching the glob pattern.
+func globToRegexp(pattern string) string {
+	var buf bytes.Buffer
+
+	buf.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			buf.WriteString(".*")
+		case '?':
+			buf.WriteString(".")
+		default:
+			buf.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	buf.WriteString("$")
+
+	return buf.String()
+}
+
+type orNode struct {
+	left, right Selector
+}
+
+func (n orNode) Matches(ps PropSet) bool {
+	return n.left.Matches(ps) || n.right.Matches(ps)
+}
+
+func (n orNode) String() string {
+	return fmt.Sprintf("(%v or %v)", n.left, n.right)
+}
+
+type andNode struct {
+	left, right Selector
+}
+
+func (n andNode) Matches(ps PropSet) bool {
+	return n.left.Matches(ps) && n.right.Matches(ps)
+}
+
+func (n andNode) String() string {
+	return fmt.Sprintf("(%v and %v)", n.left, n.right)
+}
+
+type notNode struct {
+	node Selector
+}
+
+func (n notNode) Matches(ps PropSet) bool {
+	return !n.node.Matches(ps)
+}
+
+func (n notNode) String() string {
+	return fmt.Sprintf("not %v", n.node)
+}
+
+// existsNode matches if the property is present and, for bools, true.
+type existsNode struct {
+	path string
+}
+
+func (n existsNode) Matches(ps PropSet) bool {
+	prop, ok := ps.Get(n.path)
+	if b, isBool := prop.(Bool); isBool {
+		return bool(b)
+	}
+	return ok
+}
+
+func (n existsNode) String() string {
+	return n.path
+}
+
+// testNode compares the property at path with values.
+type testNode struct {
+	path   string
+	op     string
+	values []string
+	negate bool
+
+	// compiled pattern of like, =~ and !~
+	re *regexp.Regexp
+}
+
+func (n testNode) Matches(ps PropSet) bool {
+	prop, ok := ps.Get(n.path)
+	if !ok {
+		return false
+	}
+
+	if _, ok := prop.(Map); ok {
+		return false
+	}
+
+	// a negated test of a list is true if no item passes the
+	// positive test, not if any item fails it.
+	match := false
+
+	if list, ok := prop.(List); ok {
+		for _, item := range list {
+			if n.test(item) {
+				match = true
+				break
+			}
+		}
+	} else {
+		match = n.test(prop)
+	}
+
+	if n.negated() {
+		return !match
+	}
+	return match
+}
+
+// negated returns true for !=, !~, not in and not like.
+func (n testNode) negated() bool {
+	return n.negate || n.op == "!=" || n.op == "!~"
+}
+
+// test returns the result of the positive form of the test for prop.
+func (n testNode) test(prop Property) bool {
+	if _, ok := prop.(Map); ok {
+		return false
+	}
+
+	switch n.op {
+	case "in":
+		for _, value := range n.values {
+			if equalValue(prop, value) {
+				return true
+			}
+		}
+		return false
+	case "like", "=~", "!~":
+		return n.re.MatchString(prop.String())
+	case "==", "!=":
+		return equalValue(prop, n.values[0])
+	}
+
+	cmp, ok := compareValue(prop, n.values[0])
+	if !ok {
+		return false
+	}
+
+	switch n.op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+
+	return false
+}
+
+func (n testNode) String() string {
+	values := make([]string, 0, len(n.values))
+	for _, value := range n.values {
+		values = append(values, strconv.Quote(value))
+	}
+
+	op := n.op
+	if n.negate {
+		op = "not " + op
+	}
+
+	if n.op == "in" {
+		return fmt.Sprintf("%v %v (%v)", n.path, op, strings.Join(values, ", "))
+	}
+	return fmt.Sprintf("%v %v %v", n.path, op, values[0])
+}
+
+// equalValue returns true if prop equals value, compared as
+// the property's type.
+func equalValue(prop Property, value string) bool {
+	switch prop := prop.(type) {
+	case Int:
+		n, err := strconv.Atoi(value)
+		return err == nil && int(prop) == n
+	case Bool:
+		b, err := strconv.ParseBool(value)
+		return err == nil && bool(prop) == b
+	case Time:
+		t, err := time.Parse(time.RFC3339Nano, value)
+		return err == nil && prop.Time().Equal(t)
+	}
+	return prop.String() == value
+}
+
+// compareValue orders prop and value as ints, times or strings.
+// The result is false if value can't be compared with prop.
+func compareValue(prop Property, value string) (int, bool) {
+	switch prop := prop.(type) {
+	case Int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, false
+		}
+		return compareInts(int(prop), n), true
+	case Time:
+		t, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return 0, false
+		}
+		switch {
+		case prop.Time().Before(t):
+			return -1, true
+		case prop.Time().After(t):
+			return 1, true
+		}
+		return 0, true
+	case String:
+		return strings.Compare(string(prop), value), true
+	}
+	return 0, false
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
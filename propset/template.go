@@ -0,0 +1,308 @@
+package propset
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"unicode"
+)
+
+// maximum length of a DNS label.
+const dnsLabelMax = 63
+
+// suffix of the properties that are also given without it.
+const nameSuffix = "-name"
+
+// Template renders PropSets with text/template.
+//
+// Properties are grouped by the namespace before the first '-' and the
+// rest of the name is camel-cased, so kube-namespace is .kube.namespace
+// and process-exe-sha256 is .process.exeSha256.  A name ending in -name
+// is also given without it, unless another property has that name:
+// kube-pod-name is both .kube.podName and .kube.pod.  Values are those of
+// Property.Value: maps and lists can be ranged over or indexed.
+// Referring to a property that isn't present is an error; use get for
+// optional properties.
+//
+// In addition to the text/template builtins:
+//
+//	get "docker.labels.app"   the value at a path (see PropSet.Get), or ""
+//	lookup "app" MAP          the entry of a map, or ""
+//	default "x" VALUE         VALUE, or "x" if VALUE is empty
+//	join "," LIST             the items of a list joined by a separator
+//	dns VALUE                 VALUE as a DNS label: lower case [a-z0-9-], at most 63 characters
+//	uri VALUE                 VALUE escaped for use in a URI path segment
+//	lower, upper VALUE        VALUE in lower or upper case
+//
+// A Template may be used concurrently.
+type Template struct {
+	tmpl *template.Template
+}
+
+// ParseTemplate parses text as a template.
+func ParseTemplate(text string) (*Template, error) {
+	tmpl, err := template.New("propset").
+		Option("missingkey=error").
+		Funcs(templateFuncs(nil)).
+		Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	return &Template{tmpl}, nil
+}
+
+// ParseURITemplate parses text as a template whose actions are escaped
+// for use in a URI path segment, as if each were piped to uri; the
+// text between them is left as it is.  Actions already ending in uri
+// are not escaped again.
+func ParseURITemplate(text string) (*Template, error) {
+	t, err := ParseTemplate(text)
+	if err != nil {
+		return nil, err
+	}
+	for _, tmpl := range t.tmpl.Templates() {
+		if tmpl.Tree != nil {
+			escapeActions(tmpl.Tree.Root)
+		}
+	}
+	return t, nil
+}
+
+// escapeActions appends uri to the pipeline of each action that prints
+// a value within node.
+func escapeActions(node parse.Node) {
+	switch node := node.(type) {
+	case *parse.ListNode:
+		if node == nil {
+			return
+		}
+		for _, n := range node.Nodes {
+			escapeActions(n)
+		}
+	case *parse.ActionNode:
+		pipe := node.Pipe
+		if len(pipe.Decl) > 0 || len(pipe.Cmds) == 0 {
+			// assignments print nothing.
+			return
+		}
+		last := pipe.Cmds[len(pipe.Cmds)-1]
+		if id, ok := last.Args[0].(*parse.IdentifierNode); ok && id.Ident == "uri" {
+			return
+		}
+		pipe.Cmds = append(pipe.Cmds, &parse.CommandNode{
+			NodeType: parse.NodeCommand,
+			Pos:      last.Pos,
+			Args:     []parse.Node{parse.NewIdentifier("uri").SetPos(last.Pos)},
+		})
+	case *parse.IfNode:
+		escapeActions(node.List)
+		escapeActions(node.ElseList)
+	case *parse.RangeNode:
+		escapeActions(node.List)
+		escapeActions(node.ElseList)
+	case *parse.WithNode:
+		escapeActions(node.List)
+		escapeActions(node.ElseList)
+	}
+}
+
+// Execute writes the template rendered with pset to out.
+func (t *Template) Execute(out io.Writer, pset PropSet) error {
+	// get is bound to pset; clone so that concurrent executions don't
+	// share it.
+	tmpl, err := t.tmpl.Clone()
+	if err != nil {
+		return err
+	}
+	data, err := templateData(pset)
+	if err != nil {
+		return err
+	}
+	return tmpl.Funcs(templateFuncs(pset)).Execute(out, data)
+}
+
+// Render returns the template rendered with pset.
+func (t *Template) Render(pset PropSet) (string, error) {
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, pset); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// templateData returns the values of pset grouped by namespace.  A
+// property named like a namespace is an error, as only one of them could
+// be given.
+func templateData(pset PropSet) (map[string]interface{}, error) {
+	data := make(map[string]interface{})
+	namespaces := make(map[string]map[string]interface{})
+
+	for name, prop := range pset {
+		parts := strings.SplitN(name, "-", 2)
+		if len(parts) == 1 {
+			data[name] = prop.Value()
+			continue
+		}
+
+		ns, ok := namespaces[parts[0]]
+		if !ok {
+			ns = make(map[string]interface{})
+			namespaces[parts[0]] = ns
+		}
+		ns[camelCase(parts[1])] = prop.Value()
+	}
+
+	// aliases are only added once every property is in place, so
+	// that they never hide one.
+	for name, prop := range pset {
+		parts := strings.SplitN(name, "-", 2)
+		if len(parts) == 1 || !strings.HasSuffix(parts[1], nameSuffix) {
+			continue
+		}
+
+		ns := namespaces[parts[0]]
+		alias := camelCase(strings.TrimSuffix(parts[1], nameSuffix))
+		if _, ok := ns[alias]; !ok && alias != "" {
+			ns[alias] = prop.Value()
+		}
+	}
+
+	for name, ns := range namespaces {
+		if _, ok := data[name]; ok {
+			return nil, fmt.Errorf("property %v has the name of a namespace", name)
+		}
+		data[name] = ns
+	}
+
+	return data, nil
+}
+
+// camelCase converts a dash-separated name: pod-name becomes podName.
+func camelCase(name string) string {
+	parts := strings.Split(name, "-")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+func templateFuncs(pset PropSet) template.FuncMap {
+	return template.FuncMap{
+		"get": func(path string) interface{} {
+			if prop, ok := pset.Get(path); ok {
+				return prop.Value()
+			}
+			return ""
+		},
+		"lookup":  templateLookup,
+		"default": templateDefault,
+		"join":    templateJoin,
+		"dns":     func(v interface{}) string { return dnsLabel(toString(v)) },
+		"uri":     func(v interface{}) string { return url.PathEscape(toString(v)) },
+		"lower":   func(v interface{}) string { return strings.ToLower(toString(v)) },
+		"upper":   func(v interface{}) string { return strings.ToUpper(toString(v)) },
+	}
+}
+
+func templateLookup(key string, m interface{}) interface{} {
+	if m, ok := m.(map[string]interface{}); ok {
+		if v, ok := m[key]; ok {
+			return v
+		}
+	}
+	return ""
+}
+
+func templateDefault(def interface{}, v interface{}) interface{} {
+	if v == nil {
+		return def
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String, reflect.Map, reflect.Slice:
+		if rv.Len() == 0 {
+			return def
+		}
+	}
+	return v
+}
+
+func templateJoin(sep string, v interface{}) (string, error) {
+	switch v := v.(type) {
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, toString(item))
+		}
+		return strings.Join(items, sep), nil
+	case []string:
+		return strings.Join(v, sep), nil
+	case string:
+		return v, nil
+	default:
+		return "", fmt.Errorf("join: not a list: %T", v)
+	}
+}
+
+func toString(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// dnsLabel converts s to a DNS label (RFC 1123): lower case letters,
+// digits and '-', not starting or ending with '-'.  Other characters are
+// replaced with '-'.
+func dnsLabel(s string) string {
+	label := []byte(strings.Map(func(r rune) rune {
+		r = unicode.ToLower(r)
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '-'
+	}, s))
+
+	if len(label) > dnsLabelMax {
+		label = label[:dnsLabelMax]
+	}
+
+	return strings.Trim(string(label), "-")
+}
+
+// NewTemplateEncoder returns an encoder writing each record's properties
+// rendered with tmpl, followed by a newline if the output has none.
+// Records that fail to render are skipped; the error is returned.
+func NewTemplateEncoder(out io.Writer, tmpl *Template) Encoder {
+	return &templateEncoder{out, tmpl}
+}
+
+type templateEncoder struct {
+	out  io.Writer
+	tmpl *Template
+}
+
+func (e *templateEncoder) Encode(r Record) error {
+	text, err := e.tmpl.Render(r.Props)
+	if err != nil {
+		return fmt.Errorf("process %v: %v", r.Pid, err)
+	}
+
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+
+	_, err = io.WriteString(e.out, text)
+	return err
+}
@@ -0,0 +1,186 @@
+// Package cgroup finds the container that a process belongs to
+// from its control group paths.
+//
+// Both cgroup v1 (one line per hierarchy) and v2 (a single "0::" line)
+// are supported, as are the cgroupfs and systemd drivers used by docker,
+// containerd, CRI-O and podman:
+//
+//	/docker/<id>
+//	/<containerd namespace>/<id>
+//	/system.slice/docker-<id>.scope
+//	/kubepods/burstable/pod<uid>/<id>
+//	/kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod<uid>.slice/cri-containerd-<id>.scope
+//	/kubepods.slice/.../crio-<id>.scope
+//	/machine.slice/libpod-<id>.scope
+//
+// A container may have cgroups of its own below these; a path that
+// names a second container, or names one in any other layout, is
+// rejected.
+package cgroup
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+const (
+	RuntimeDocker     = "docker"
+	RuntimeContainerd = "containerd"
+	RuntimeCRIO       = "cri-o"
+	RuntimePodman     = "podman"
+)
+
+var (
+	ErrNotFound = errors.New("no container cgroup found")
+
+	// A container may create cgroups below its own, if they are
+	// delegated to it, and so must not be able to name another
+	// container there.
+	ErrAmbiguous    = errors.New("cgroup names more than one container")
+	ErrUnrecognized = errors.New("cgroup names a container in an unrecognized layout")
+)
+
+// Container identifies the container that a process is running in.
+type Container struct {
+	// Container ID.
+	ID string
+
+	// Container runtime, if it can be determined from the cgroup path.
+	Runtime string
+
+	// Kubernetes pod UID, if the container belongs to a pod.
+	PodUID string
+}
+
+var (
+	// a container ID in one of the layouts of its runtime.
+	prefixedIDExp = regexp.MustCompile(`^(docker|cri-containerd|crio|libpod)-([0-9a-f]{64})(?:\.scope)?$`)
+	bareIDExp     = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+	// anything that looks like a container ID.
+	anyIDExp = regexp.MustCompile(`[0-9a-f]{64}`)
+
+	// cgroupfs: pod<uid>, systemd: kubepods-<qos>-pod<uid with underscores>.slice
+	podExp = regexp.MustCompile(`pod([0-9a-f]{8}[-_][0-9a-f]{4}[-_][0-9a-f]{4}[-_][0-9a-f]{4}[-_][0-9a-f]{12})(?:\.slice)?$`)
+
+	runtimePrefixes = map[string]string{
+		"docker":         RuntimeDocker,
+		"cri-containerd": RuntimeContainerd,
+		"crio":           RuntimeCRIO,
+		"libpod":         RuntimePodman,
+	}
+)
+
+// ForPid returns the container that the given process is running in.
+func ForPid(pid int) (Container, error) {
+	file, err := os.Open(fmt.Sprintf("/proc/%v/cgroup", pid))
+	if err != nil {
+		return Container{}, err
+	}
+	defer file.Close()
+
+	return Parse(file)
+}
+
+// Parse returns the container found in the contents of a /proc/<pid>/cgroup file.
+// Every hierarchy that names a container must name the same one.
+func Parse(r io.Reader) (Container, error) {
+	scanner := bufio.NewScanner(r)
+
+	var found *Container
+
+	for scanner.Scan() {
+		// hierarchy-ID:controller-list:cgroup-path
+		parts := strings.SplitN(scanner.Text(), ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+
+		c, err := ParsePath(parts[2])
+		switch {
+		case err == ErrNotFound:
+			continue
+		case err != nil:
+			return Container{}, err
+		case found != nil && found.ID != c.ID:
+			return Container{}, ErrAmbiguous
+		case found == nil:
+			found = &c
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return Container{}, err
+	}
+
+	if found == nil {
+		return Container{}, ErrNotFound
+	}
+
+	return *found, nil
+}
+
+// ParsePath returns the container found in a single cgroup path.  The
+// container is named by the outermost segment that holds an ID; the ID
+// must be in one of the layouts above, and no other segment may hold one.
+func ParsePath(path string) (Container, error) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	for i, segment := range segments {
+		if !anyIDExp.MatchString(segment) {
+			continue
+		}
+
+		c, ok := parseSegment(segments[:i], segment)
+		if !ok {
+			return Container{}, ErrUnrecognized
+		}
+
+		for _, child := range segments[i+1:] {
+			if anyIDExp.MatchString(child) {
+				return Container{}, ErrAmbiguous
+			}
+		}
+
+		return c, nil
+	}
+
+	return Container{}, ErrNotFound
+}
+
+// parseSegment returns the container named by segment, given its
+// parents.  A bare ID is only accepted directly below the root
+// (/docker/<id>, /<containerd namespace>/<id>) or a pod.
+func parseSegment(parents []string, segment string) (Container, bool) {
+	var c Container
+
+	if m := prefixedIDExp.FindStringSubmatch(segment); m != nil {
+		c = Container{ID: m[2], Runtime: runtimePrefixes[m[1]]}
+	} else if bareIDExp.MatchString(segment) {
+		switch {
+		case len(parents) == 1:
+		case len(parents) > 0 && podExp.MatchString(parents[len(parents)-1]):
+		default:
+			return Container{}, false
+		}
+		c = Container{ID: segment}
+	} else {
+		return Container{}, false
+	}
+
+	for _, parent := range parents {
+		if parent == "docker" && c.Runtime == "" {
+			c.Runtime = RuntimeDocker
+		}
+		if pm := podExp.FindStringSubmatch(parent); pm != nil {
+			c.PodUID = strings.Replace(pm[1], "_", "-", -1)
+		}
+	}
+
+	return c, true
+}
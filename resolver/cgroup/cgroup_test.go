@@ -0,0 +1,60 @@
+package cgroup
+
+import (
+	"strings"
+	"testing"
+)
+
+const (
+	idA = "1111111111111111111111111111111111111111111111111111111111111111"
+	idB = "2222222222222222222222222222222222222222222222222222222222222222"
+)
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		path    string
+		id      string
+		runtime string
+		err     error
+	}{
+		{"/", "", "", ErrNotFound},
+		{"/user.slice/user-1000.slice/session-1.scope", "", "", ErrNotFound},
+		{"/docker/" + idA, idA, RuntimeDocker, nil},
+		{"/system.slice/docker-" + idA + ".scope", idA, RuntimeDocker, nil},
+		{"/default/" + idA, idA, "", nil},
+		{"/kubepods/burstable/pod01234567-89ab-cdef-0123-456789abcdef/" + idA, idA, "", nil},
+		{"/kubepods.slice/kubepods-pod01234567_89ab_cdef_0123_456789abcdef.slice/cri-containerd-" + idA + ".scope", idA, RuntimeContainerd, nil},
+		{"/machine.slice/libpod-" + idA + ".scope/container", idA, RuntimePodman, nil},
+
+		// a container's own cgroups may not name another container.
+		{"/docker/" + idA + "/" + idB, "", "", ErrAmbiguous},
+		{"/system.slice/docker-" + idA + ".scope/docker-" + idB + ".scope", "", "", ErrAmbiguous},
+		{"/system.slice/docker-" + idA + ".scope/x-" + idB, "", "", ErrAmbiguous},
+
+		{"/user.slice/" + idA + ".scope", "", "", ErrUnrecognized},
+		{"/a/b/" + idA, "", "", ErrUnrecognized},
+	}
+
+	for _, test := range tests {
+		c, err := ParsePath(test.path)
+		if err != test.err {
+			t.Errorf("%v: error %v, want %v", test.path, err, test.err)
+			continue
+		}
+		if c.ID != test.id || c.Runtime != test.runtime {
+			t.Errorf("%v: got %+v", test.path, c)
+		}
+	}
+}
+
+func TestParseRejectsConflictingHierarchies(t *testing.T) {
+	file := strings.Join([]string{
+		"12:pids:/docker/" + idA,
+		"11:memory:/docker/" + idB,
+		"1:name=systemd:/docker/" + idA,
+	}, "\n")
+
+	if c, err := Parse(strings.NewReader(file)); err != ErrAmbiguous {
+		t.Errorf("got %+v, %v", c, err)
+	}
+}
@@ -0,0 +1,255 @@
+package containerd
+
+import (
+	"net"
+	"strings"
+	"time"
+
+	context "golang.org/x/net/context"
+
+	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// containerd services and the gRPC metadata key selecting the namespace
+// of a request.  The generated messages don't include the services.
+const (
+	namespaceKey = "containerd-namespace"
+
+	versionService    = "containerd.services.version.v1.Version"
+	namespacesService = "containerd.services.namespaces.v1.Namespaces"
+	containersService = "containerd.services.containers.v1.Containers"
+	tasksService      = "containerd.services.tasks.v1.Tasks"
+	eventsService     = "containerd.services.events.v1.Events"
+)
+
+// client calls the containerd services used by the resolver.
+type client struct {
+	conn *grpc.ClientConn
+}
+
+func dial(ctx context.Context, endpoint string) (*client, error) {
+	path := strings.TrimPrefix(endpoint, "unix://")
+
+	dialer := func(addr string, timeout time.Duration) (net.Conn, error) {
+		return net.DialTimeout("unix", addr, timeout)
+	}
+
+	conn, err := grpc.DialContext(ctx, path, grpc.WithInsecure(), grpc.WithDialer(dialer))
+	if err != nil {
+		return nil, err
+	}
+
+	return &client{conn}, nil
+}
+
+func (c *client) Version(ctx context.Context) (*VersionResponse, error) {
+	out := new(VersionResponse)
+	if err := c.invoke(ctx, versionService, "Version", &Empty{}, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *client) ListNamespaces(ctx context.Context) (*ListNamespacesResponse, error) {
+	out := new(ListNamespacesResponse)
+	if err := c.invoke(ctx, namespacesService, "List", &ListNamespacesRequest{}, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *client) GetContainer(ctx context.Context, namespace, id string) (*GetContainerResponse, error) {
+	ctx = withNamespace(ctx, namespace)
+	out := new(GetContainerResponse)
+	if err := c.invoke(ctx, containersService, "Get", &GetContainerRequest{Id: id}, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *client) ListTasks(ctx context.Context, namespace string) (*ListTasksResponse, error) {
+	ctx = withNamespace(ctx, namespace)
+	out := new(ListTasksResponse)
+	if err := c.invoke(ctx, tasksService, "List", &ListTasksRequest{}, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *client) GetTask(ctx context.Context, namespace, id string) (*GetTaskResponse, error) {
+	ctx = withNamespace(ctx, namespace)
+	out := new(GetTaskResponse)
+	if err := c.invoke(ctx, tasksService, "Get", &GetTaskRequest{ContainerId: id}, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Subscribe streams events from all namespaces matching any of the given filters.
+func (c *client) Subscribe(ctx context.Context, filters []string) (*eventStream, error) {
+	desc := &grpc.StreamDesc{StreamName: "Subscribe", ServerStreams: true}
+
+	stream, err := grpc.NewClientStream(ctx, desc, c.conn, "/"+eventsService+"/Subscribe")
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.SendMsg(&SubscribeRequest{Filters: filters}); err != nil {
+		return nil, err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return &eventStream{stream}, nil
+}
+
+func (c *client) Close() error {
+	return c.conn.Close()
+}
+
+func (c *client) invoke(ctx context.Context, service, method string, in, out interface{}) error {
+	return grpc.Invoke(ctx, "/"+service+"/"+method, in, out, c.conn)
+}
+
+type eventStream struct {
+	grpc.ClientStream
+}
+
+func (s *eventStream) Recv() (*Envelope, error) {
+	m := new(Envelope)
+	if err := s.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func withNamespace(ctx context.Context, namespace string) context.Context {
+	return metadata.NewOutgoingContext(ctx, metadata.Pairs(namespaceKey, namespace))
+}
+
+// NamespaceFromContext returns the namespace of a request received by a Server.
+func NamespaceFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md[namespaceKey]) == 0 {
+		return ""
+	}
+	return md[namespaceKey][0]
+}
+
+// Server implements the subset of the containerd API used by the resolver.
+// It allows the resolver to be run against a fake containerd.
+type Server interface {
+	Version(context.Context, *Empty) (*VersionResponse, error)
+	ListNamespaces(context.Context, *ListNamespacesRequest) (*ListNamespacesResponse, error)
+	GetContainer(context.Context, *GetContainerRequest) (*GetContainerResponse, error)
+	ListTasks(context.Context, *ListTasksRequest) (*ListTasksResponse, error)
+	GetTask(context.Context, *GetTaskRequest) (*GetTaskResponse, error)
+	Subscribe(*SubscribeRequest, EventsSubscribeServer) error
+}
+
+type EventsSubscribeServer interface {
+	Send(*Envelope) error
+	grpc.ServerStream
+}
+
+// RegisterServer registers srv as the containerd version, namespaces,
+// containers, tasks and events services of s.
+func RegisterServer(s *grpc.Server, srv Server) {
+	s.RegisterService(&grpc.ServiceDesc{
+		ServiceName: versionService,
+		HandlerType: (*Server)(nil),
+		Methods: []grpc.MethodDesc{
+			unaryMethod("Version", func(srv Server, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+				in := new(Empty)
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+				return srv.Version(ctx, in)
+			}),
+		},
+	}, srv)
+
+	s.RegisterService(&grpc.ServiceDesc{
+		ServiceName: namespacesService,
+		HandlerType: (*Server)(nil),
+		Methods: []grpc.MethodDesc{
+			unaryMethod("List", func(srv Server, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+				in := new(ListNamespacesRequest)
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+				return srv.ListNamespaces(ctx, in)
+			}),
+		},
+	}, srv)
+
+	s.RegisterService(&grpc.ServiceDesc{
+		ServiceName: containersService,
+		HandlerType: (*Server)(nil),
+		Methods: []grpc.MethodDesc{
+			unaryMethod("Get", func(srv Server, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+				in := new(GetContainerRequest)
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+				return srv.GetContainer(ctx, in)
+			}),
+		},
+	}, srv)
+
+	s.RegisterService(&grpc.ServiceDesc{
+		ServiceName: tasksService,
+		HandlerType: (*Server)(nil),
+		Methods: []grpc.MethodDesc{
+			unaryMethod("List", func(srv Server, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+				in := new(ListTasksRequest)
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+				return srv.ListTasks(ctx, in)
+			}),
+			unaryMethod("Get", func(srv Server, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+				in := new(GetTaskRequest)
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+				return srv.GetTask(ctx, in)
+			}),
+		},
+	}, srv)
+
+	s.RegisterService(&grpc.ServiceDesc{
+		ServiceName: eventsService,
+		HandlerType: (*Server)(nil),
+		Streams: []grpc.StreamDesc{
+			{StreamName: "Subscribe", Handler: subscribeHandler, ServerStreams: true},
+		},
+	}, srv)
+}
+
+// unaryMethod returns the description of a unary method whose request is
+// decoded and handled by fn.  Server interceptors are not supported.
+func unaryMethod(method string, fn func(Server, context.Context, func(interface{}) error) (interface{}, error)) grpc.MethodDesc {
+	return grpc.MethodDesc{
+		MethodName: method,
+		Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+			return fn(srv.(Server), ctx, dec)
+		},
+	}
+}
+
+func subscribeHandler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(Server).Subscribe(m, &eventsSubscribeServer{stream})
+}
+
+type eventsSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (s *eventsSubscribeServer) Send(m *Envelope) error {
+	return s.ServerStream.SendMsg(m)
+}
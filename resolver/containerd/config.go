@@ -0,0 +1,68 @@
+package containerd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	defaultEndpoint      = "/run/containerd/containerd.sock"
+	defaultLookupTimeout = time.Second
+	defaultPeriod        = 10 * time.Second
+	defaultTimeout       = 5 * time.Second
+)
+
+// Config configures the containerd resolver.
+type Config struct {
+	// Path of the containerd socket.
+	Endpoint string `yaml:"endpoint"`
+
+	// Only containers in these namespaces are tracked.  If empty, all
+	// namespaces are (e.g. "default", "k8s.io", "moby").
+	Namespaces []string `yaml:"namespaces"`
+
+	// How long a lookup waits for the container of a PID to be found.
+	LookupTimeout time.Duration `yaml:"lookup-timeout"`
+
+	// How often the full list of tasks is fetched.
+	ListPeriod time.Duration `yaml:"list-period"`
+
+	// Timeout for requests to containerd.
+	RequestTimeout time.Duration `yaml:"request-timeout"`
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Endpoint:       defaultEndpoint,
+		LookupTimeout:  defaultLookupTimeout,
+		ListPeriod:     defaultPeriod,
+		RequestTimeout: defaultTimeout,
+	}
+}
+
+func (c Config) Validate() error {
+	if c.Endpoint == "" {
+		return errors.New("endpoint: required")
+	}
+
+	for idx, ns := range c.Namespaces {
+		if ns == "" {
+			return fmt.Errorf("namespaces[%v]: empty namespace", idx)
+		}
+	}
+
+	if c.LookupTimeout <= 0 {
+		return fmt.Errorf("lookup-timeout: must be positive (got %v)", c.LookupTimeout)
+	}
+
+	if c.ListPeriod <= 0 {
+		return fmt.Errorf("list-period: must be positive (got %v)", c.ListPeriod)
+	}
+
+	if c.RequestTimeout <= 0 {
+		return fmt.Errorf("request-timeout: must be positive (got %v)", c.RequestTimeout)
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package containerd
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/boz/circumspect/resolver/registry"
+	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+var errTaskNotRunning = errors.New("task not running")
+
+// NewContainer returns a Monitor that fetches the task and metadata of
+// the given container and submits the results to the registry.  It
+// shuts itself down once its task is no longer running.
+func NewContainer(ctx context.Context, client *client, reg Registry, key Key, timeout time.Duration) registry.Monitor {
+	return registry.NewMonitor(ctx, registry.MonitorConfig{
+		Key: key,
+		Fetch: func(ctx context.Context) (interface{}, error) {
+			return fetchInfo(ctx, client, key)
+		},
+		Submit: func(info interface{}) error {
+			return reg.Submit(info.(Info))
+		},
+		Gone: func(err error) bool {
+			return err == errTaskNotRunning || grpc.Code(err) == codes.NotFound
+		},
+		Timeout: timeout,
+		Log: pkglog.WithField("containerd-namespace", key.Namespace).
+			WithField("containerd-id", key.ID),
+	})
+}
+
+func fetchInfo(ctx context.Context, client *client, key Key) (Info, error) {
+	task, err := client.GetTask(ctx, key.Namespace, key.ID)
+	if err != nil {
+		return Info{}, err
+	}
+
+	if !acceptTask(task.GetProcess()) {
+		return Info{}, errTaskNotRunning
+	}
+
+	resp, err := client.GetContainer(ctx, key.Namespace, key.ID)
+	if err != nil {
+		return Info{}, err
+	}
+
+	return Info{
+		Key:    key,
+		Pid:    int(task.GetProcess().GetPid()),
+		Image:  resp.GetContainer().GetImage(),
+		Labels: resp.GetContainer().GetLabels(),
+	}, nil
+}
@@ -0,0 +1,11 @@
+package containerd
+
+// Key identifies a container.  Container IDs are only unique within a namespace.
+type Key struct {
+	Namespace string
+	ID        string
+}
+
+func (k Key) String() string {
+	return k.Namespace + "/" + k.ID
+}
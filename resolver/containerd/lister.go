@@ -0,0 +1,182 @@
+package containerd
+
+import (
+	"context"
+	"time"
+
+	"github.com/boz/circumspect/resolver/registry"
+	"github.com/sirupsen/logrus"
+)
+
+// Lister periodically fetches the complete list of running
+// tasks and sends their keys to the `Containers()` channel.
+type Lister interface {
+	Containers() <-chan []Key
+	Shutdown()
+	Done() <-chan struct{}
+}
+
+func NewLister(ctx context.Context, client *client, namespaces []string, period time.Duration, timeout time.Duration) Lister {
+	log := pkglog.WithField("component", "lister")
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	lister := &lister{
+		client:     client,
+		namespaces: namespaces,
+		period:     period,
+		timeout:    timeout,
+		outch:      make(chan []Key),
+		donech:     make(chan struct{}),
+		log:        log,
+		cancel:     cancel,
+		ctx:        ctx,
+	}
+
+	go lister.run()
+
+	return lister
+}
+
+type lister struct {
+	client     *client
+	namespaces []string
+	period     time.Duration
+	timeout    time.Duration
+
+	outch chan []Key
+
+	err    error
+	donech chan struct{}
+	log    logrus.FieldLogger
+	cancel context.CancelFunc
+	ctx    context.Context
+}
+
+func (l *lister) Containers() <-chan []Key {
+	return l.outch
+}
+
+func (l *lister) Shutdown() {
+	l.cancel()
+	<-l.donech
+}
+
+func (l *lister) Done() <-chan struct{} {
+	return l.donech
+}
+
+func (l *lister) run() {
+	defer close(l.donech)
+	defer l.cancel()
+	defer l.log.Debug("done")
+
+	var ticker *time.Timer
+	var tickch <-chan time.Time
+
+	var keys []Key
+	var outch chan []Key
+
+	runner := newListRunner(l.ctx, l.client, l.namespaces, l.timeout)
+	runnerch := runner.Done()
+
+loop:
+
+	for {
+
+		select {
+
+		case <-l.ctx.Done():
+			break loop
+
+		case <-runnerch:
+			if err := runner.Err(); err != nil {
+				l.log.WithError(err).Error("runner failed")
+				l.err = err
+				break loop
+			}
+
+			keys = runner.Result().([]Key)
+
+			l.log.Debugf("list complete: %v tasks found", len(keys))
+
+			// deliver empty lists too: stale containers are purged
+			// and the service is marked ready on idle hosts.
+			outch = l.outch
+
+			runner = nil
+			runnerch = nil
+
+			ticker = time.NewTimer(l.period)
+			tickch = ticker.C
+
+		case <-tickch:
+			tickch = nil
+
+			l.log.Debug("starting runner")
+			runner = newListRunner(l.ctx, l.client, l.namespaces, l.timeout)
+			runnerch = runner.Done()
+
+		case outch <- keys:
+			l.log.Debugf("%v tasks delivered", len(keys))
+
+			keys = nil
+			outch = nil
+
+		}
+
+	}
+
+	if ticker != nil {
+		ticker.Stop()
+	}
+
+	if runner != nil {
+		l.log.Debug("draining runner")
+		<-runner.Done()
+		l.err = runner.Err()
+	}
+}
+
+// newListRunner lists the running tasks in the given namespaces,
+// or in all namespaces if none are given.
+func newListRunner(ctx context.Context, client *client, namespaces []string, timeout time.Duration) registry.Runner {
+	return registry.NewRunner(ctx, timeout, func(ctx context.Context) (interface{}, error) {
+		current := namespaces
+
+		if len(current) == 0 {
+			resp, err := client.ListNamespaces(ctx)
+			if err != nil {
+				return nil, err
+			}
+			for _, ns := range resp.GetNamespaces() {
+				current = append(current, ns.GetName())
+			}
+		}
+
+		var keys []Key
+
+		for _, ns := range current {
+			resp, err := client.ListTasks(ctx, ns)
+			if err != nil {
+				return nil, err
+			}
+			for _, task := range resp.GetTasks() {
+				if acceptTask(task) {
+					keys = append(keys, Key{ns, task.GetContainerId()})
+				}
+			}
+		}
+
+		return keys, nil
+	})
+}
+
+func acceptTask(task *Process) bool {
+	switch task.GetStatus() {
+	case TaskStatus_RUNNING, TaskStatus_PAUSED, TaskStatus_PAUSING:
+		return task.GetPid() > 0
+	default:
+		return false
+	}
+}
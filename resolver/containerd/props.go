@@ -0,0 +1,46 @@
+package containerd
+
+import "github.com/boz/circumspect/propset"
+
+type Props interface {
+	ContainerdID() string
+	ContainerdNamespace() string
+	ContainerdImage() string
+	ContainerdLabels() map[string]string
+
+	PropSet() propset.PropSet
+}
+
+// Info is the state of a running container as submitted to the Registry.
+type Info struct {
+	Key
+	Pid    int
+	Image  string
+	Labels map[string]string
+}
+
+type makeProps Info
+
+func (p makeProps) ContainerdID() string {
+	return p.ID
+}
+
+func (p makeProps) ContainerdNamespace() string {
+	return p.Namespace
+}
+
+func (p makeProps) ContainerdImage() string {
+	return p.Image
+}
+
+func (p makeProps) ContainerdLabels() map[string]string {
+	return p.Labels
+}
+
+func (p makeProps) PropSet() propset.PropSet {
+	return propset.New().
+		AddString("containerd-id", p.ContainerdID()).
+		AddString("containerd-namespace", p.ContainerdNamespace()).
+		AddString("containerd-image", p.ContainerdImage()).
+		AddMap("containerd-labels", p.ContainerdLabels())
+}
@@ -0,0 +1,82 @@
+package containerd
+
+import (
+	"context"
+	"time"
+
+	"github.com/boz/circumspect/resolver/cgroup"
+	"github.com/boz/circumspect/resolver/registry"
+)
+
+var (
+	ErrInvalidPid = registry.ErrInvalidPid
+	ErrNotFound   = registry.ErrNotFound
+	ErrNotRunning = registry.ErrNotRunning
+)
+
+// Registry contains a set of running containers and
+// allows for finding which container a PID belongs to, if any.
+type Registry interface {
+
+	// Lookup will try to find the container that is running the given PID.
+	// See registry.Registry; the parent PIDs are searched for a
+	// container's task PID.
+	Lookup(ctx context.Context, pid int) (Props, error)
+
+	// Submit notifies the registry of a new or updated container.
+	Submit(Info) error
+
+	// Remove removes a container that is no longer running.
+	Remove(Key) error
+
+	// Watch returns a channel that is signalled each time a changed
+	// version of the given container is submitted.
+	// The watch is removed when the given context is cancelled.
+	Watch(ctx context.Context, key Key) <-chan struct{}
+
+	Shutdown()
+	Done() <-chan struct{}
+}
+
+type containerRegistry struct {
+	registry.Registry
+}
+
+// NewRegistry returns a Registry of containers in all namespaces.
+//
+// Containers created by docker and the containerd CRI plugin are
+// also containerd containers (in the "moby" and "k8s.io" namespaces),
+// so their cgroups are searched too.
+func NewRegistry(ctx context.Context, lookupTimeout time.Duration) Registry {
+	return containerRegistry{registry.New(ctx, registry.Config{
+		Runtime:        "containerd",
+		CgroupRuntimes: []string{cgroup.RuntimeDocker, cgroup.RuntimeContainerd},
+		LookupTimeout:  lookupTimeout,
+		Log:            pkglog.WithField("component", "registry"),
+	})}
+}
+
+func (r containerRegistry) Lookup(ctx context.Context, pid int) (Props, error) {
+	c, err := r.Registry.Lookup(ctx, pid)
+	if err != nil {
+		return nil, err
+	}
+	return makeProps(c.Value.(Info)), nil
+}
+
+func (r containerRegistry) Submit(info Info) error {
+	return r.Registry.Submit(registry.Container{
+		Key:   info.Key,
+		ID:    info.ID,
+		Pid:   info.Pid,
+		Value: info,
+	})
+}
+
+func (r containerRegistry) Remove(key Key) error {
+	return r.Registry.Remove(key)
+}
+
+func (r containerRegistry) Watch(ctx context.Context, key Key) <-chan struct{} {
+	return r.Registry.Watch(ctx, key)
+}
@@ -0,0 +1,306 @@
+package containerd
+
+import (
+	"context"
+	"time"
+
+	"github.com/boz/circumspect/resolver/registry"
+	"github.com/sirupsen/logrus"
+)
+
+var pkglog = logrus.StandardLogger().WithField("package", "resolver/containerd")
+
+type RequiredProps interface {
+	Pid() int
+}
+
+// Service maintains a set of running containerd containers and a registry
+// for searching for containers by PID.  Like the docker resolver, it learns
+// of running containers from a Lister, which periodically lists tasks in all
+// namespaces, and a Watcher, which subscribes to task and container events.
+type Service interface {
+	Lookup(context.Context, RequiredProps) (Props, error)
+
+	// Watch signals each time the given container is refreshed with
+	// changed attributes or is removed. See Registry.Watch.
+	Watch(ctx context.Context, namespace, id string) <-chan struct{}
+
+	// Ready is closed once the first list of running tasks has been received.
+	Ready() <-chan struct{}
+
+	Shutdown()
+	Done() <-chan struct{}
+}
+
+func NewService(ctx context.Context, cfg Config) (Service, error) {
+	log := pkglog.WithField("component", "service")
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	client, err := dial(ctx, cfg.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	vctx, vcancel := context.WithTimeout(ctx, cfg.RequestTimeout)
+	defer vcancel()
+
+	version, err := client.Version(vctx)
+	if err != nil {
+		log.WithError(err).Errorf("can't connect to containerd at %v", cfg.Endpoint)
+		client.Close()
+		return nil, err
+	}
+
+	log.WithField("containerd-version", version.GetVersion()).
+		Debug("connected to containerd")
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	lister := NewLister(ctx, client, cfg.Namespaces, cfg.ListPeriod, cfg.RequestTimeout)
+	watcher := NewWatcher(ctx, client, cfg.Namespaces)
+	reg := NewRegistry(ctx, cfg.LookupTimeout)
+
+	svc := &service{
+		client:   client,
+		timeout:  cfg.RequestTimeout,
+		lister:   lister,
+		watcher:  watcher,
+		registry: reg,
+
+		containers:      make(map[Key]registry.Monitor),
+		containerch:     make(chan registry.Monitor),
+		staleContainers: make(map[Key]registry.Monitor),
+
+		log:     log,
+		cancel:  cancel,
+		ctx:     ctx,
+		readych: make(chan struct{}),
+		donech:  make(chan struct{}),
+	}
+
+	go svc.run()
+
+	return svc, nil
+}
+
+type service struct {
+	client   *client
+	timeout  time.Duration
+	lister   Lister
+	watcher  registry.Watcher
+	registry Registry
+
+	containers  map[Key]registry.Monitor
+	containerch chan registry.Monitor
+
+	// Containers that have been missing from one lister.Containers() delivery
+	staleContainers map[Key]registry.Monitor
+
+	log     logrus.FieldLogger
+	readych chan struct{}
+	donech  chan struct{}
+	cancel  context.CancelFunc
+	ctx     context.Context
+}
+
+func (s *service) Lookup(ctx context.Context, pprops RequiredProps) (Props, error) {
+	return s.registry.Lookup(ctx, pprops.Pid())
+}
+
+func (s *service) Watch(ctx context.Context, namespace, id string) <-chan struct{} {
+	return s.registry.Watch(ctx, Key{namespace, id})
+}
+
+func (s *service) Ready() <-chan struct{} {
+	return s.readych
+}
+
+func (s *service) Shutdown() {
+	s.cancel()
+	<-s.donech
+}
+
+func (s *service) Done() <-chan struct{} {
+	return s.donech
+}
+
+func (s *service) run() {
+	defer close(s.donech)
+	defer s.log.Debug("done")
+
+loop:
+	for {
+		select {
+
+		case <-s.ctx.Done():
+			s.log.Debug("context cancelled")
+			break loop
+
+		case <-s.lister.Done():
+			s.log.Debug("early lister completion")
+			break loop
+
+		case <-s.watcher.Done():
+			s.log.Debug("early watcher completion")
+			break loop
+
+		case <-s.registry.Done():
+			s.log.Debug("early registry completion")
+			break loop
+
+		case c := <-s.containerch:
+			s.log.WithField("containerd-id", c.Key().(Key).ID).
+				Debug("container complete")
+
+			s.removeContainer(c)
+
+		case keys := <-s.lister.Containers():
+			s.handleContainerList(keys)
+			s.markReady()
+
+		case event := <-s.watcher.Events():
+			s.handleWatchEvent(event)
+		}
+	}
+
+	s.cancel()
+
+	s.log.Debugf("draining %v containers", len(s.containers))
+
+	// drain containers
+	for len(s.containers) > 0 {
+
+		c := <-s.containerch
+
+		s.log.WithField("containerd-id", c.Key().(Key).ID).
+			Debug("container drained")
+
+		s.removeContainer(c)
+	}
+
+	<-s.lister.Done()
+	<-s.watcher.Done()
+	<-s.registry.Done()
+
+	s.client.Close()
+}
+
+// handleContainerList updates the current set of running containers.
+// A new Container will be created for all new keys given.
+// If a currently running container is not in the new list, it will
+// be marked as "stale".
+// If a "stale" container is not in the list, it will be shut down.
+func (s *service) handleContainerList(keys []Key) {
+	s.log.WithField("active", len(s.containers)).
+		WithField("stale", len(s.staleContainers)).
+		Debugf("updating with %v containers", len(keys))
+
+	newset := make(map[Key]bool)
+
+	for _, key := range keys {
+
+		newset[key] = true
+
+		// no longer stale
+		delete(s.staleContainers, key)
+
+		// already created
+		if _, ok := s.containers[key]; ok {
+			continue
+		}
+
+		// new container
+		s.createContainer(key)
+
+	}
+
+	// handle containers not in new list
+	for key, c := range s.containers {
+
+		if newset[key] {
+			continue
+		}
+
+		// already stale once. purge.
+		if _, ok := s.staleContainers[key]; ok {
+			s.log.WithField("containerd-id", key.ID).Debug("shutting down stale container")
+			c.Shutdown()
+			continue
+		}
+
+		// queue up to be purged on the next list
+		s.staleContainers[key] = c
+	}
+}
+
+func (s *service) markReady() {
+	select {
+	case <-s.readych:
+	default:
+		s.log.Debug("ready")
+		close(s.readych)
+	}
+}
+
+func (s *service) handleWatchEvent(event registry.WatchEvent) {
+	key := event.Key.(Key)
+
+	s.log.WithField("containerd-namespace", key.Namespace).
+		WithField("containerd-id", key.ID).
+		WithField("event-type", event.Type).
+		Debug("watcher event received")
+
+	switch event.Type {
+	case registry.EventTypeCreate, registry.EventTypeUpdate:
+		s.refreshContainer(key)
+	case registry.EventTypeDelete:
+		s.purgeContainer(key)
+	}
+}
+
+func (s *service) refreshContainer(key Key) {
+	if c, ok := s.containers[key]; ok && c.Refresh() == nil {
+		return
+	}
+	// new, or replacing one that has completed.
+	s.createContainer(key)
+}
+
+func (s *service) purgeContainer(key Key) {
+	if c, ok := s.containers[key]; ok {
+		c.Shutdown()
+	}
+}
+
+// removeContainer removes a completed container from the registry
+// unless it has already been replaced by a new one with the same key.
+func (s *service) removeContainer(c registry.Monitor) {
+	key := c.Key().(Key)
+	if s.containers[key] != c {
+		return
+	}
+	delete(s.containers, key)
+	delete(s.staleContainers, key)
+	s.registry.Remove(key)
+}
+
+func (s *service) createContainer(key Key) {
+	log := s.log.WithField("containerd-namespace", key.Namespace).
+		WithField("containerd-id", key.ID)
+	log.Debug("creating container")
+
+	c := NewContainer(s.ctx, s.client, s.registry, key, s.timeout)
+	s.containers[key] = c
+
+	go func() {
+		<-c.Done()
+		select {
+		case s.containerch <- c:
+		case <-s.donech:
+			// replaced and not drained.
+		}
+	}()
+}
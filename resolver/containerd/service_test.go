@@ -0,0 +1,171 @@
+package containerd
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	context "golang.org/x/net/context"
+
+	"github.com/boz/circumspect/resolver/cgroup"
+	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeServer runs a single container in a single namespace.
+type fakeServer struct {
+	namespace string
+	id        string
+	pid       int
+	image     string
+	labels    map[string]string
+}
+
+func (s *fakeServer) Version(context.Context, *Empty) (*VersionResponse, error) {
+	return &VersionResponse{Version: "fake"}, nil
+}
+
+func (s *fakeServer) ListNamespaces(context.Context, *ListNamespacesRequest) (*ListNamespacesResponse, error) {
+	return &ListNamespacesResponse{Namespaces: []*Namespace{{Name: s.namespace}}}, nil
+}
+
+func (s *fakeServer) GetContainer(ctx context.Context, req *GetContainerRequest) (*GetContainerResponse, error) {
+	if NamespaceFromContext(ctx) != s.namespace || req.GetId() != s.id {
+		return nil, status.Error(codes.NotFound, "container not found")
+	}
+	return &GetContainerResponse{Container: &ContainerRecord{
+		Id:     s.id,
+		Image:  s.image,
+		Labels: s.labels,
+	}}, nil
+}
+
+func (s *fakeServer) ListTasks(ctx context.Context, _ *ListTasksRequest) (*ListTasksResponse, error) {
+	if NamespaceFromContext(ctx) != s.namespace {
+		return &ListTasksResponse{}, nil
+	}
+	return &ListTasksResponse{Tasks: []*Process{s.task()}}, nil
+}
+
+func (s *fakeServer) GetTask(ctx context.Context, req *GetTaskRequest) (*GetTaskResponse, error) {
+	if NamespaceFromContext(ctx) != s.namespace || req.GetContainerId() != s.id {
+		return nil, status.Error(codes.NotFound, "task not found")
+	}
+	return &GetTaskResponse{Process: s.task()}, nil
+}
+
+func (s *fakeServer) Subscribe(_ *SubscribeRequest, stream EventsSubscribeServer) error {
+	<-stream.Context().Done()
+	return nil
+}
+
+func (s *fakeServer) task() *Process {
+	return &Process{
+		ContainerId: s.id,
+		Id:          s.id,
+		Pid:         uint32(s.pid),
+		Status:      TaskStatus_RUNNING,
+	}
+}
+
+type pidProps int
+
+func (p pidProps) Pid() int {
+	return int(p)
+}
+
+func TestServiceLookup(t *testing.T) {
+	dir, err := ioutil.TempDir("", "containerd-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	sock, err := net.Listen("unix", filepath.Join(dir, "containerd.sock"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the container's task.
+	cmd := exec.Command("sleep", "30")
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer cmd.Wait()
+	defer cmd.Process.Kill()
+
+	fake := &fakeServer{
+		namespace: "test",
+		id:        "test-container",
+		pid:       cmd.Process.Pid,
+		image:     "docker.io/library/busybox:latest",
+		labels:    map[string]string{"app": "test"},
+	}
+
+	// when run in a container, the task is found by the id in its cgroup.
+	cg, err := cgroup.ForPid(fake.pid)
+	if err == nil && cg.ID != "" {
+		fake.id = cg.ID
+	}
+
+	srv := grpc.NewServer()
+	RegisterServer(srv, fake)
+	go srv.Serve(sock)
+	defer srv.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg := DefaultConfig()
+	cfg.Endpoint = "unix://" + sock.Addr().String()
+	cfg.LookupTimeout = 2 * time.Second
+
+	svc, err := NewService(ctx, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer svc.Shutdown()
+
+	select {
+	case <-svc.Ready():
+	case <-time.After(5 * time.Second):
+		t.Fatal("service not ready")
+	}
+
+	props, err := svc.Lookup(ctx, pidProps(fake.pid))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := props.ContainerdID(); got != fake.id {
+		t.Errorf("ContainerdID() = %q, want %q", got, fake.id)
+	}
+	if got := props.ContainerdNamespace(); got != fake.namespace {
+		t.Errorf("ContainerdNamespace() = %q, want %q", got, fake.namespace)
+	}
+	if got := props.ContainerdImage(); got != fake.image {
+		t.Errorf("ContainerdImage() = %q, want %q", got, fake.image)
+	}
+	if got := props.ContainerdLabels()["app"]; got != "test" {
+		t.Errorf("ContainerdLabels()[app] = %q, want %q", got, "test")
+	}
+
+	pset := props.PropSet()
+	if got, _ := pset.GetString("containerd.labels.app"); got != "test" {
+		t.Errorf("containerd.labels.app = %q, want %q", got, "test")
+	}
+
+	if cg.ID != "" {
+		// the test process is in the same container.
+		return
+	}
+
+	if _, err := svc.Lookup(ctx, pidProps(os.Getpid())); err != ErrNotFound {
+		t.Errorf("Lookup(self) error = %v, want %v", err, ErrNotFound)
+	}
+}
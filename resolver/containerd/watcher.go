@@ -0,0 +1,95 @@
+package containerd
+
+import (
+	"context"
+
+	"github.com/boz/circumspect/resolver/registry"
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	topicTaskStart       = "/tasks/start"
+	topicTaskExit        = "/tasks/exit"
+	topicTaskDelete      = "/tasks/delete"
+	topicTaskPaused      = "/tasks/paused"
+	topicTaskResumed     = "/tasks/resumed"
+	topicContainerUpdate = "/containers/update"
+	topicContainerDelete = "/containers/delete"
+)
+
+// watcherTopics maps the containerd event topics that are watched
+// to the resulting event type.  A container is refreshed when its task exits
+// rather than deleted; the task may belong to an exec'd process.
+var watcherTopics = map[string]registry.EventType{
+	topicTaskStart:       registry.EventTypeCreate,
+	topicTaskExit:        registry.EventTypeUpdate,
+	topicTaskDelete:      registry.EventTypeUpdate,
+	topicTaskPaused:      registry.EventTypeUpdate,
+	topicTaskResumed:     registry.EventTypeUpdate,
+	topicContainerUpdate: registry.EventTypeUpdate,
+	topicContainerDelete: registry.EventTypeDelete,
+}
+
+// NewWatcher returns a Watcher for containerd's task and container
+// events in the given namespaces, or in all namespaces if none are
+// given.  Events are keyed by Key.
+func NewWatcher(ctx context.Context, client *client, namespaces []string) registry.Watcher {
+	accept := make(map[string]bool)
+	for _, ns := range namespaces {
+		accept[ns] = true
+	}
+
+	var filters []string
+	for topic := range watcherTopics {
+		filters = append(filters, `topic=="`+topic+`"`)
+	}
+
+	return registry.NewWatcher(ctx, func(ctx context.Context) (registry.EventStream, error) {
+		stream, err := client.Subscribe(ctx, filters)
+		if err != nil {
+			return nil, err
+		}
+		return &watchStream{stream, accept}, nil
+	}, pkglog)
+}
+
+type watchStream struct {
+	stream     *eventStream
+	namespaces map[string]bool
+}
+
+func (s *watchStream) Next() (registry.WatchEvent, bool, error) {
+	envelope, err := s.stream.Recv()
+	if err != nil {
+		return registry.WatchEvent{}, false, err
+	}
+
+	etype, ok := watcherTopics[envelope.GetTopic()]
+	if !ok {
+		return registry.WatchEvent{}, false, nil
+	}
+
+	if len(s.namespaces) > 0 && !s.namespaces[envelope.GetNamespace()] {
+		return registry.WatchEvent{}, false, nil
+	}
+
+	// all watched events carry the container id in field 1.
+	event := &EventContainerID{}
+	if err := proto.Unmarshal(envelope.GetEvent().GetValue(), event); err != nil {
+		pkglog.WithError(err).
+			WithField("topic", envelope.GetTopic()).
+			Warn("error decoding event")
+		return registry.WatchEvent{}, false, nil
+	}
+
+	if event.GetContainerId() == "" {
+		return registry.WatchEvent{}, false, nil
+	}
+
+	return registry.WatchEvent{Type: etype, Key: Key{envelope.GetNamespace(), event.GetContainerId()}}, true, nil
+}
+
+// Close does nothing; the stream ends when its context is cancelled.
+func (s *watchStream) Close() error {
+	return nil
+}
@@ -0,0 +1,65 @@
+package cri
+
+import (
+	"net"
+	"strings"
+	"time"
+
+	context "golang.org/x/net/context"
+
+	grpc "google.golang.org/grpc"
+)
+
+// client calls the RuntimeService of a CRI runtime.  The generated
+// messages don't include the service as its name depends on the API version.
+type client struct {
+	conn    *grpc.ClientConn
+	service string
+}
+
+func dial(ctx context.Context, endpoint string, service string) (*client, error) {
+	path := strings.TrimPrefix(endpoint, "unix://")
+
+	dialer := func(addr string, timeout time.Duration) (net.Conn, error) {
+		return net.DialTimeout("unix", addr, timeout)
+	}
+
+	conn, err := grpc.DialContext(ctx, path, grpc.WithInsecure(), grpc.WithDialer(dialer))
+	if err != nil {
+		return nil, err
+	}
+
+	return &client{conn, service}, nil
+}
+
+func (c *client) Version(ctx context.Context) (*VersionResponse, error) {
+	out := new(VersionResponse)
+	if err := c.invoke(ctx, "Version", &VersionRequest{}, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *client) ListContainers(ctx context.Context, in *ListContainersRequest) (*ListContainersResponse, error) {
+	out := new(ListContainersResponse)
+	if err := c.invoke(ctx, "ListContainers", in, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *client) PodSandboxStatus(ctx context.Context, in *PodSandboxStatusRequest) (*PodSandboxStatusResponse, error) {
+	out := new(PodSandboxStatusResponse)
+	if err := c.invoke(ctx, "PodSandboxStatus", in, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *client) Close() error {
+	return c.conn.Close()
+}
+
+func (c *client) invoke(ctx context.Context, method string, in, out interface{}) error {
+	return grpc.Invoke(ctx, "/"+c.service+"/"+method, in, out, c.conn)
+}
@@ -0,0 +1,55 @@
+package cri
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	defaultEndpoint = "/run/containerd/containerd.sock"
+	defaultTimeout  = 2 * time.Second
+)
+
+// CRI API versions and their gRPC service names.
+var apiServices = map[string]string{
+	"v1":       "runtime.v1.RuntimeService",
+	"v1alpha2": "runtime.v1alpha2.RuntimeService",
+}
+
+// Config configures the CRI resolver.
+type Config struct {
+	// Path of the runtime's CRI socket; e.g. /run/containerd/containerd.sock
+	// or /var/run/crio/crio.sock.
+	Endpoint string `yaml:"endpoint"`
+
+	// CRI API version: v1 or v1alpha2.
+	APIVersion string `yaml:"api-version"`
+
+	// Timeout for requests to the runtime.
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Endpoint:   defaultEndpoint,
+		APIVersion: "v1",
+		Timeout:    defaultTimeout,
+	}
+}
+
+func (c Config) Validate() error {
+	if c.Endpoint == "" {
+		return errors.New("endpoint: required")
+	}
+
+	if _, ok := apiServices[c.APIVersion]; !ok {
+		return fmt.Errorf("api-version: unsupported version %q (v1, v1alpha2)", c.APIVersion)
+	}
+
+	if c.Timeout <= 0 {
+		return fmt.Errorf("timeout: must be positive (got %v)", c.Timeout)
+	}
+
+	return nil
+}
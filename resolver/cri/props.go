@@ -0,0 +1,74 @@
+package cri
+
+import "github.com/boz/circumspect/propset"
+
+type Props interface {
+	CRIRuntime() string
+	CRIContainerID() string
+	CRIContainerName() string
+	CRIImage() string
+	CRILabels() map[string]string
+	CRIPodName() string
+	CRIPodNamespace() string
+	CRIPodUID() string
+
+	// ContainerID returns the runtime-qualified container ID, as used
+	// in kubernetes pod status; e.g. "containerd://<id>".
+	ContainerID() string
+
+	PropSet() propset.PropSet
+}
+
+type props struct {
+	runtime   string
+	container *Container
+	sandbox   *PodSandboxStatus
+}
+
+func (p props) CRIRuntime() string {
+	return p.runtime
+}
+
+func (p props) CRIContainerID() string {
+	return p.container.GetId()
+}
+
+func (p props) CRIContainerName() string {
+	return p.container.GetMetadata().GetName()
+}
+
+func (p props) CRIImage() string {
+	return p.container.GetImage().GetImage()
+}
+
+func (p props) CRILabels() map[string]string {
+	return p.container.GetLabels()
+}
+
+func (p props) CRIPodName() string {
+	return p.sandbox.GetMetadata().GetName()
+}
+
+func (p props) CRIPodNamespace() string {
+	return p.sandbox.GetMetadata().GetNamespace()
+}
+
+func (p props) CRIPodUID() string {
+	return p.sandbox.GetMetadata().GetUid()
+}
+
+func (p props) ContainerID() string {
+	return p.runtime + "://" + p.container.GetId()
+}
+
+func (p props) PropSet() propset.PropSet {
+	return propset.New().
+		AddString("cri-runtime", p.CRIRuntime()).
+		AddString("cri-container-id", p.CRIContainerID()).
+		AddString("cri-container-name", p.CRIContainerName()).
+		AddString("cri-image", p.CRIImage()).
+		AddMap("cri-labels", p.CRILabels()).
+		AddString("cri-pod-name", p.CRIPodName()).
+		AddString("cri-pod-namespace", p.CRIPodNamespace()).
+		AddString("cri-pod-uid", p.CRIPodUID())
+}
@@ -0,0 +1,169 @@
+package cri
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/boz/circumspect/resolver/cgroup"
+	"github.com/sirupsen/logrus"
+	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+var (
+	ErrNotFound   = errors.New("container not found")
+	ErrInvalidPid = errors.New("invalid PID")
+
+	pkglog = logrus.StandardLogger().WithField("package", "resolver/cri")
+)
+
+type RequiredProps interface {
+	Pid() int
+}
+
+// Service resolves processes running in containers managed by a
+// CRI runtime (containerd, CRI-O).  The container is found from the
+// process's cgroup and its metadata is fetched from the runtime.
+type Service interface {
+	Lookup(context.Context, RequiredProps) (Props, error)
+
+	// Ready is closed once the runtime has been contacted.
+	Ready() <-chan struct{}
+
+	Shutdown()
+	Done() <-chan struct{}
+}
+
+func NewService(ctx context.Context, cfg Config) (Service, error) {
+	log := pkglog.WithField("component", "service")
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	client, err := dial(ctx, cfg.Endpoint, apiServices[cfg.APIVersion])
+	if err != nil {
+		return nil, err
+	}
+
+	vctx, vcancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer vcancel()
+
+	version, err := client.Version(vctx)
+	if err != nil {
+		log.WithError(err).Errorf("can't connect to runtime at %v", cfg.Endpoint)
+		client.Close()
+		return nil, err
+	}
+
+	log.WithField("runtime", version.GetRuntimeName()).
+		WithField("runtime-version", version.GetRuntimeVersion()).
+		Debug("connected to runtime")
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	s := &service{
+		client:  client,
+		runtime: version.GetRuntimeName(),
+		timeout: cfg.Timeout,
+		readych: make(chan struct{}),
+		donech:  make(chan struct{}),
+		log:     log,
+		cancel:  cancel,
+		ctx:     ctx,
+	}
+
+	close(s.readych)
+
+	go s.run()
+
+	return s, nil
+}
+
+type service struct {
+	client  *client
+	runtime string
+	timeout time.Duration
+	readych chan struct{}
+	donech  chan struct{}
+	log     logrus.FieldLogger
+	cancel  context.CancelFunc
+	ctx     context.Context
+}
+
+func (s *service) Lookup(ctx context.Context, pprops RequiredProps) (Props, error) {
+	log := s.log.WithField("pid", pprops.Pid())
+
+	cgc, err := cgroup.ForPid(pprops.Pid())
+	switch {
+	case err == cgroup.ErrNotFound:
+		return nil, ErrNotFound
+	case err != nil:
+		log.WithError(err).Debug("reading cgroup")
+		return nil, ErrInvalidPid
+	}
+
+	log = log.WithField("container-id", cgc.ID)
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	list, err := s.client.ListContainers(ctx, &ListContainersRequest{
+		Filter: &ContainerFilter{Id: cgc.ID},
+	})
+	if err != nil {
+		log.WithError(err).Warn("listing containers")
+		return nil, convertError(err)
+	}
+
+	if len(list.GetContainers()) == 0 {
+		// not managed by this runtime's CRI plugin.
+		return nil, ErrNotFound
+	}
+
+	container := list.GetContainers()[0]
+
+	sandbox, err := s.client.PodSandboxStatus(ctx, &PodSandboxStatusRequest{
+		PodSandboxId: container.GetPodSandboxId(),
+	})
+	if err != nil {
+		log.WithError(err).Warn("fetching pod sandbox status")
+		return nil, convertError(err)
+	}
+
+	log.WithField("cri-pod-name", sandbox.GetStatus().GetMetadata().GetName()).
+		Debug("container found")
+
+	return props{s.runtime, container, sandbox.GetStatus()}, nil
+}
+
+func (s *service) Ready() <-chan struct{} {
+	return s.readych
+}
+
+func (s *service) Shutdown() {
+	s.cancel()
+	<-s.donech
+}
+
+func (s *service) Done() <-chan struct{} {
+	return s.donech
+}
+
+func (s *service) run() {
+	defer close(s.donech)
+	defer s.log.Debug("done")
+
+	<-s.ctx.Done()
+
+	s.client.Close()
+}
+
+// convertError returns context.DeadlineExceeded for timed out requests.
+func convertError(err error) error {
+	if grpc.Code(err) == codes.DeadlineExceeded {
+		return context.DeadlineExceeded
+	}
+	return err
+}
@@ -0,0 +1,76 @@
+package docker
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	defaultLookupTimeout = time.Second
+	defaultPeriod        = 10 * time.Second
+	defaultTimeout       = 5 * time.Second
+)
+
+// Config configures the docker resolver.
+type Config struct {
+	// Docker daemon address.  If empty, the address, API version and
+	// TLS settings are read from the environment (DOCKER_HOST etc...)
+	Host string `yaml:"host"`
+
+	// API version to use with Host.
+	APIVersion string `yaml:"api-version"`
+
+	// Only containers with one of these statuses are tracked.
+	Status []string `yaml:"status"`
+
+	// How long a lookup waits for the container of a PID to be found.
+	LookupTimeout time.Duration `yaml:"lookup-timeout"`
+
+	// How often the full list of containers is fetched.
+	ListPeriod time.Duration `yaml:"list-period"`
+
+	// Timeout for requests to the docker daemon.
+	RequestTimeout time.Duration `yaml:"request-timeout"`
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Status:         []string{"running"},
+		LookupTimeout:  defaultLookupTimeout,
+		ListPeriod:     defaultPeriod,
+		RequestTimeout: defaultTimeout,
+	}
+}
+
+func (c Config) Validate() error {
+	if c.APIVersion != "" && c.Host == "" {
+		return errors.New("api-version: requires host")
+	}
+
+	if len(c.Status) == 0 {
+		return errors.New("status: at least one status required")
+	}
+
+	for idx, status := range c.Status {
+		switch status {
+		case "created", "restarting", "running", "paused", "exited", "dead":
+		default:
+			return fmt.Errorf("status[%v]: invalid status %q", idx, status)
+		}
+	}
+
+	if c.LookupTimeout <= 0 {
+		return fmt.Errorf("lookup-timeout: must be positive (got %v)", c.LookupTimeout)
+	}
+
+	if c.ListPeriod <= 0 {
+		return fmt.Errorf("list-period: must be positive (got %v)", c.ListPeriod)
+	}
+
+	if c.RequestTimeout <= 0 {
+		return fmt.Errorf("request-timeout: must be positive (got %v)", c.RequestTimeout)
+	}
+
+	return nil
+}
@@ -1,9 +0,0 @@
-package docker
-
-const (
-	EventTypeCreate EventType = "create"
-	EventTypeUpdate EventType = "update"
-	EventTypeDelete EventType = "delete"
-)
-
-type EventType string
@@ -4,17 +4,13 @@ import (
 	"context"
 	"time"
 
+	"github.com/boz/circumspect/resolver/registry"
 	"github.com/docker/engine-api/client"
 	"github.com/docker/engine-api/types"
 	"github.com/docker/engine-api/types/filters"
 	"github.com/sirupsen/logrus"
 )
 
-const (
-	defaultPeriod  = 10 * time.Second
-	defaultTimeout = 5 * time.Second
-)
-
 // Lister periodically fetches the complete list
 // of running containers and sends them to the `Containers()` channel.
 type Lister interface {
@@ -23,20 +19,21 @@ type Lister interface {
 	Done() <-chan struct{}
 }
 
-func NewLister(ctx context.Context, client *client.Client, filter filters.Args) Lister {
+func NewLister(ctx context.Context, client *client.Client, filter filters.Args, period time.Duration, timeout time.Duration) Lister {
 	log := pkglog.WithField("component", "lister")
 
 	ctx, cancel := context.WithCancel(ctx)
 
 	lister := &lister{
-		client: client,
-		filter: filter,
-		period: defaultPeriod,
-		outch:  make(chan []types.Container),
-		donech: make(chan struct{}),
-		log:    log,
-		cancel: cancel,
-		ctx:    ctx,
+		client:  client,
+		filter:  filter,
+		period:  period,
+		timeout: timeout,
+		outch:   make(chan []types.Container),
+		donech:  make(chan struct{}),
+		log:     log,
+		cancel:  cancel,
+		ctx:     ctx,
 	}
 
 	go lister.run()
@@ -45,9 +42,10 @@ func NewLister(ctx context.Context, client *client.Client, filter filters.Args)
 }
 
 type lister struct {
-	client *client.Client
-	filter filters.Args
-	period time.Duration
+	client  *client.Client
+	filter  filters.Args
+	period  time.Duration
+	timeout time.Duration
 
 	outch chan []types.Container
 
@@ -82,7 +80,7 @@ func (l *lister) run() {
 	var containers []types.Container
 	var outch chan []types.Container
 
-	runner := newListRunner(l.ctx, l.client, l.filter)
+	runner := newListRunner(l.ctx, l.client, l.filter, l.timeout)
 	runnerch := runner.Done()
 
 loop:
@@ -121,7 +119,7 @@ loop:
 			tickch = nil
 
 			l.log.Debug("starting runner")
-			runner = newListRunner(l.ctx, l.client, l.filter)
+			runner = newListRunner(l.ctx, l.client, l.filter, l.timeout)
 			runnerch = runner.Done()
 
 		case outch <- containers:
@@ -145,8 +143,8 @@ loop:
 	}
 }
 
-func newListRunner(ctx context.Context, client *client.Client, filter filters.Args) Runner {
-	return NewRunner(ctx, func(ctx context.Context) (interface{}, error) {
+func newListRunner(ctx context.Context, client *client.Client, filter filters.Args, timeout time.Duration) registry.Runner {
+	return registry.NewRunner(ctx, timeout, func(ctx context.Context) (interface{}, error) {
 		options := types.ContainerListOptions{
 			Filter: filter,
 			All:    true,
@@ -1,16 +1,26 @@
 package docker
 
 import (
+	"strings"
+	"time"
+
 	"github.com/boz/circumspect/propset"
 	"github.com/docker/engine-api/types"
 )
 
 type Props interface {
 	DockerID() string
+	DockerName() string
 	DockerPid() int
 	DockerImage() string
 	DockerPath() string
+	DockerArgs() []string
 	DockerLabels() map[string]string
+	DockerPrivileged() bool
+
+	// DockerStartedAt returns the time the container was last started,
+	// or the zero time if it is not known.
+	DockerStartedAt() time.Time
 
 	PropSet() propset.PropSet
 }
@@ -21,6 +31,10 @@ func (p makeProps) DockerID() string {
 	return p.ID
 }
 
+func (p makeProps) DockerName() string {
+	return strings.TrimPrefix(p.Name, "/")
+}
+
 func (p makeProps) DockerPid() int {
 	return p.State.Pid
 }
@@ -33,15 +47,37 @@ func (p makeProps) DockerPath() string {
 	return p.Path
 }
 
+func (p makeProps) DockerArgs() []string {
+	return p.Args
+}
+
 func (p makeProps) DockerLabels() map[string]string {
 	return p.Config.Labels
 }
 
+func (p makeProps) DockerPrivileged() bool {
+	return p.HostConfig != nil && p.HostConfig.Privileged
+}
+
+func (p makeProps) DockerStartedAt() time.Time {
+	started, _ := time.Parse(time.RFC3339Nano, p.State.StartedAt)
+	return started
+}
+
 func (p makeProps) PropSet() propset.PropSet {
-	return propset.New().
+	pset := propset.New().
 		AddString("docker-id", p.DockerID()).
+		AddString("docker-name", p.DockerName()).
 		AddInt("docker-pid", p.DockerPid()).
 		AddString("docker-image", p.DockerImage()).
 		AddString("docker-path", p.DockerPath()).
-		AddMap("docker-labels", p.DockerLabels())
+		AddStrings("docker-args", p.DockerArgs()).
+		AddMap("docker-labels", p.DockerLabels()).
+		AddBool("docker-privileged", p.DockerPrivileged())
+
+	if started := p.DockerStartedAt(); !started.IsZero() {
+		pset.AddTime("docker-started-at", started)
+	}
+
+	return pset
 }
@@ -2,7 +2,9 @@ package docker
 
 import (
 	"context"
+	"time"
 
+	"github.com/boz/circumspect/resolver/registry"
 	"github.com/docker/engine-api/client"
 	"github.com/docker/engine-api/types"
 	"github.com/docker/engine-api/types/filters"
@@ -26,15 +28,26 @@ type RequiredProps interface {
 // and make sure any missed "container died" events don't cause memory/container leaks.
 type Service interface {
 	Lookup(context.Context, RequiredProps) (Props, error)
+
+	// Watch signals each time the container with the given id is refreshed
+	// with changed attributes. See Registry.Watch.
+	Watch(ctx context.Context, id string) <-chan struct{}
+
+	// Ready is closed once the first list of running containers has been received.
+	Ready() <-chan struct{}
+
 	Shutdown()
 	Done() <-chan struct{}
 }
 
-func NewService(ctx context.Context) (Service, error) {
+func NewService(ctx context.Context, cfg Config) (Service, error) {
 	log := pkglog.WithField("component", "service")
 
-	// todo: configurable
-	client, err := client.NewEnvClient()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	client, err := newClient(cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -47,30 +60,33 @@ func NewService(ctx context.Context) (Service, error) {
 
 	log.Debugf("connected to docker %v", ping.Name)
 
-	// todo: configurable
 	filter := filters.NewArgs()
-	filter.Add("status", "running")
+	for _, status := range cfg.Status {
+		filter.Add("status", status)
+	}
 
 	ctx, cancel := context.WithCancel(ctx)
 
-	lister := NewLister(ctx, client, filter)
+	lister := NewLister(ctx, client, filter, cfg.ListPeriod, cfg.RequestTimeout)
 	watcher := NewWatcher(ctx, client, filter)
-	registry := NewRegistry(ctx)
+	reg := NewRegistry(ctx, cfg.LookupTimeout)
 
 	svc := &service{
 		client:   client,
+		timeout:  cfg.RequestTimeout,
 		lister:   lister,
 		watcher:  watcher,
-		registry: registry,
+		registry: reg,
 
-		containers:      make(map[string]Container),
-		containerch:     make(chan Container),
-		staleContainers: make(map[string]Container),
+		containers:      make(map[string]registry.Monitor),
+		containerch:     make(chan registry.Monitor),
+		staleContainers: make(map[string]registry.Monitor),
 
-		log:    log,
-		cancel: cancel,
-		ctx:    ctx,
-		donech: make(chan struct{}),
+		log:     log,
+		cancel:  cancel,
+		ctx:     ctx,
+		readych: make(chan struct{}),
+		donech:  make(chan struct{}),
 	}
 
 	go svc.run()
@@ -80,26 +96,36 @@ func NewService(ctx context.Context) (Service, error) {
 
 type service struct {
 	client   *client.Client
+	timeout  time.Duration
 	lister   Lister
-	watcher  Watcher
+	watcher  registry.Watcher
 	registry Registry
 
-	containers  map[string]Container
-	containerch chan Container
+	containers  map[string]registry.Monitor
+	containerch chan registry.Monitor
 
 	// Containers that have been missing from one lister.Containers() delivery
-	staleContainers map[string]Container
+	staleContainers map[string]registry.Monitor
 
-	log    logrus.FieldLogger
-	donech chan struct{}
-	cancel context.CancelFunc
-	ctx    context.Context
+	log     logrus.FieldLogger
+	readych chan struct{}
+	donech  chan struct{}
+	cancel  context.CancelFunc
+	ctx     context.Context
 }
 
 func (s *service) Lookup(ctx context.Context, pprops RequiredProps) (Props, error) {
 	return s.registry.Lookup(ctx, pprops.Pid())
 }
 
+func (s *service) Watch(ctx context.Context, id string) <-chan struct{} {
+	return s.registry.Watch(ctx, id)
+}
+
+func (s *service) Ready() <-chan struct{} {
+	return s.readych
+}
+
 func (s *service) Shutdown() {
 	s.cancel()
 	<-s.donech
@@ -134,14 +160,17 @@ loop:
 			break loop
 
 		case c := <-s.containerch:
-			s.log.WithField("docker-id", c.ID()).
+			id := c.Key().(string)
+
+			s.log.WithField("docker-id", id).
 				Debug("container complete")
 
-			delete(s.containers, c.ID())
-			delete(s.staleContainers, c.ID())
+			delete(s.containers, id)
+			delete(s.staleContainers, id)
 
 		case containers := <-s.lister.Containers():
 			s.handleContainerList(containers)
+			s.markReady()
 
 		case event := <-s.watcher.Events():
 			s.handleWatchEvent(event)
@@ -156,12 +185,13 @@ loop:
 	for len(s.containers) > 0 {
 
 		c := <-s.containerch
+		id := c.Key().(string)
 
-		s.log.WithField("docker-id", c.ID()).
+		s.log.WithField("docker-id", id).
 			Debug("container drained")
 
-		delete(s.containers, c.ID())
-		delete(s.staleContainers, c.ID())
+		delete(s.containers, id)
+		delete(s.staleContainers, id)
 	}
 
 	<-s.lister.Done()
@@ -217,16 +247,27 @@ func (s *service) handleContainerList(containers []types.Container) {
 	}
 }
 
-func (s *service) handleWatchEvent(event WatchEvent) {
-	s.log.WithField("docker-id", event.ID).
+func (s *service) markReady() {
+	select {
+	case <-s.readych:
+	default:
+		s.log.Debug("ready")
+		close(s.readych)
+	}
+}
+
+func (s *service) handleWatchEvent(event registry.WatchEvent) {
+	id := event.Key.(string)
+
+	s.log.WithField("docker-id", id).
 		WithField("event-type", event.Type).
 		Debug("watcher event received")
 
 	switch event.Type {
-	case EventTypeCreate, EventTypeUpdate:
-		s.refreshContainer(event.ID)
-	case EventTypeDelete:
-		s.purgeContainer(event.ID)
+	case registry.EventTypeCreate, registry.EventTypeUpdate:
+		s.refreshContainer(id)
+	case registry.EventTypeDelete:
+		s.purgeContainer(id)
 	}
 }
 
@@ -248,11 +289,24 @@ func (s *service) createContainer(id string) {
 	log := s.log.WithField("docker-id", id)
 	log.Debug("creating container")
 
-	c := NewContainer(s.ctx, s.client, s.registry, id)
-	s.containers[c.ID()] = c
+	c := NewContainer(s.ctx, s.client, s.registry, id, s.timeout)
+	s.containers[id] = c
 
 	go func() {
 		<-c.Done()
 		s.containerch <- c
 	}()
 }
+
+func newClient(cfg Config) (*client.Client, error) {
+	if cfg.Host == "" {
+		return client.NewEnvClient()
+	}
+
+	version := cfg.APIVersion
+	if version == "" {
+		version = client.DefaultVersion
+	}
+
+	return client.NewClient(cfg.Host, version, nil, nil)
+}
@@ -3,145 +3,54 @@ package docker
 import (
 	"context"
 	"encoding/json"
-	"fmt"
+	"io"
 
+	"github.com/boz/circumspect/resolver/registry"
 	"github.com/docker/engine-api/client"
 	"github.com/docker/engine-api/types"
 	"github.com/docker/engine-api/types/events"
 	"github.com/docker/engine-api/types/filters"
-	"github.com/sirupsen/logrus"
 )
 
-const (
-	watcherDefaultBufsiz = 20
-	watcherActionStart   = "start"
-	watcherActionDie     = "die"
-)
-
-// Watcher watches for "start", "die" container
-// events in Docker and exposes them via the Events()
-// method.
-type Watcher interface {
-	Events() <-chan WatchEvent
-	Shutdown()
-	Err() error
-	Done() <-chan struct{}
-}
-
-type WatchEvent struct {
-	Type EventType
-	ID   string
-}
-
-func NewWatcher(ctx context.Context, client *client.Client, filter filters.Args) Watcher {
-	ctx, cancel := context.WithCancel(ctx)
-
-	w := &watcher{
-		client:  client,
-		filter:  filter,
-		eventch: make(chan WatchEvent, watcherDefaultBufsiz),
-		donech:  make(chan struct{}),
-		log:     pkglog.WithField("component", "watcher"),
-		cancel:  cancel,
-		ctx:     ctx,
-	}
-
-	go w.run()
-
-	return w
-}
-
-type watcher struct {
-	client  *client.Client
-	filter  filters.Args
-	eventch chan WatchEvent
-	donech  chan struct{}
-	err     error
-	log     logrus.FieldLogger
-	cancel  context.CancelFunc
-	ctx     context.Context
-}
-
-func (w *watcher) Events() <-chan WatchEvent {
-	return w.eventch
-}
-
-func (w *watcher) Shutdown() {
-	w.cancel()
-	<-w.donech
+// watcherActions maps the container events that are watched to the
+// resulting event type.
+var watcherActions = map[string]registry.EventType{
+	"start": registry.EventTypeCreate,
+	"die":   registry.EventTypeDelete,
 }
 
-func (w *watcher) Done() <-chan struct{} {
-	return w.donech
+// NewWatcher returns a Watcher for "start" and "die" container
+// events in Docker.
+func NewWatcher(ctx context.Context, client *client.Client, filter filters.Args) registry.Watcher {
+	return registry.NewWatcher(ctx, func(ctx context.Context) (registry.EventStream, error) {
+		body, err := client.Events(ctx, types.EventsOptions{Filters: filter})
+		if err != nil {
+			return nil, err
+		}
+		return &eventStream{body, json.NewDecoder(body)}, nil
+	}, pkglog)
 }
 
-func (w *watcher) Err() error {
-	<-w.donech
-	return w.err
+type eventStream struct {
+	body    io.ReadCloser
+	decoder *json.Decoder
 }
 
-func (w *watcher) run() {
-	defer close(w.donech)
-	defer w.log.Debug("done")
-	defer w.cancel()
+func (s *eventStream) Next() (registry.WatchEvent, bool, error) {
+	var event events.Message
 
-	options := types.EventsOptions{
-		Filters: w.filter,
+	if err := s.decoder.Decode(&event); err != nil {
+		return registry.WatchEvent{}, false, err
 	}
 
-	// todo: retry, throttle
-	stream, err := w.client.Events(w.ctx, options)
-	if err != nil {
-		pkglog.WithError(err).Error("error getting events")
-		return
+	etype, ok := watcherActions[event.Action]
+	if !ok || event.Actor.ID == "" {
+		return registry.WatchEvent{}, false, nil
 	}
-	defer stream.Close()
-
-	var event events.Message
-
-	decoder := json.NewDecoder(stream)
-
-	for w.ctx.Err() == nil {
-
-		if err := decoder.Decode(&event); err != nil {
-			if w.ctx.Err() == nil {
-				pkglog.WithError(err).Error("error decoding stream")
-			}
-			return
-		}
 
-		options.Since = fmt.Sprintf("%d.%09d", event.Time, event.TimeNano)
-
-		var wevent WatchEvent
-
-		switch event.Action {
-		case watcherActionStart:
-			wevent = WatchEvent{EventTypeCreate, event.Actor.ID}
-		case watcherActionDie:
-			wevent = WatchEvent{EventTypeDelete, event.Actor.ID}
-		default:
-			continue
-		}
-
-		select {
-		case w.eventch <- wevent:
-		default:
-			pkglog.Warn("dropping event")
-		}
-	}
+	return registry.WatchEvent{Type: etype, Key: event.Actor.ID}, true, nil
 }
 
-func watcherAcceptEvent(event events.Message) bool {
-	switch {
-	case event.Type != events.ContainerEventType:
-		return false
-	case event.Actor.ID == "":
-		return false
-	case event.Action == watcherActionStart:
-		return true
-	case event.Action == watcherActionDie:
-		return true
-	default:
-		return false
-	}
+func (s *eventStream) Close() error {
+	return s.body.Close()
 }
@@ -0,0 +1,48 @@
+package kube
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	defaultSyncPeriod   = time.Minute
+	defaultNamespace    = "default"
+	defaultQueryTimeout = time.Second
+)
+
+// Config configures the kube resolver.
+type Config struct {
+	// Path to a kubeconfig file.  If empty, the in-cluster configuration
+	// is used, falling back to the default kubeconfig loading rules.
+	Kubeconfig string `yaml:"kubeconfig"`
+
+	// Namespace to watch pods in.  If empty, all namespaces are watched.
+	Namespace string `yaml:"namespace"`
+
+	// How often the pod cache is fully resynced.
+	SyncPeriod time.Duration `yaml:"sync-period"`
+
+	// How long a lookup waits for a pod to be found.
+	QueryTimeout time.Duration `yaml:"query-timeout"`
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Namespace:    defaultNamespace,
+		SyncPeriod:   defaultSyncPeriod,
+		QueryTimeout: defaultQueryTimeout,
+	}
+}
+
+func (c Config) Validate() error {
+	if c.SyncPeriod <= 0 {
+		return fmt.Errorf("sync-period: must be positive (got %v)", c.SyncPeriod)
+	}
+
+	if c.QueryTimeout <= 0 {
+		return fmt.Errorf("query-timeout: must be positive (got %v)", c.QueryTimeout)
+	}
+
+	return nil
+}
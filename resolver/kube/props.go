@@ -1,6 +1,8 @@
 package kube
 
 import (
+	"time"
+
 	"github.com/boz/circumspect/propset"
 	"k8s.io/api/core/v1"
 )
@@ -8,9 +10,22 @@ import (
 type Props interface {
 	KubeNamespace() string
 	KubePodName() string
+	KubePodUID() string
 	KubeLabels() map[string]string
 	KubeAnnotations() map[string]string
 	KubeContainerName() string
+	KubeContainerImage() string
+	KubeServiceAccount() string
+	KubeRestartCount() int
+	KubeReady() bool
+
+	// KubeStartedAt returns the time the container was last started,
+	// or the zero time if it is not running.
+	KubeStartedAt() time.Time
+
+	// KubeOwner returns the kind and name of the controller of the pod,
+	// if it has one.
+	KubeOwner() (kind, name string, ok bool)
 
 	PropSet() propset.PropSet
 }
@@ -32,6 +47,10 @@ func (p props) KubePodName() string {
 	return p.pod.Name
 }
 
+func (p props) KubePodUID() string {
+	return string(p.pod.UID)
+}
+
 func (p props) KubeLabels() map[string]string {
 	return p.pod.Labels
 }
@@ -44,11 +63,59 @@ func (p props) KubeContainerName() string {
 	return p.cs.Name
 }
 
+func (p props) KubeContainerImage() string {
+	return p.cs.Image
+}
+
+func (p props) KubeServiceAccount() string {
+	return p.pod.Spec.ServiceAccountName
+}
+
+func (p props) KubeRestartCount() int {
+	return int(p.cs.RestartCount)
+}
+
+func (p props) KubeReady() bool {
+	return p.cs.Ready
+}
+
+func (p props) KubeStartedAt() time.Time {
+	if p.cs.State.Running == nil {
+		return time.Time{}
+	}
+	return p.cs.State.Running.StartedAt.Time
+}
+
+func (p props) KubeOwner() (string, string, bool) {
+	for _, ref := range p.pod.OwnerReferences {
+		if ref.Controller != nil && *ref.Controller {
+			return ref.Kind, ref.Name, true
+		}
+	}
+	return "", "", false
+}
+
 func (p props) PropSet() propset.PropSet {
-	return propset.New().
+	pset := propset.New().
 		AddString("kube-namespace", p.KubeNamespace()).
 		AddString("kube-pod-name", p.KubePodName()).
+		AddString("kube-pod-uid", p.KubePodUID()).
 		AddMap("kube-labels", p.KubeLabels()).
 		AddMap("kube-annotations", p.KubeAnnotations()).
-		AddString("kube-container-name", p.KubeContainerName())
+		AddString("kube-container-name", p.KubeContainerName()).
+		AddString("kube-container-image", p.KubeContainerImage()).
+		AddString("kube-service-account", p.KubeServiceAccount()).
+		AddInt("kube-restart-count", p.KubeRestartCount()).
+		AddBool("kube-ready", p.KubeReady())
+
+	if started := p.KubeStartedAt(); !started.IsZero() {
+		pset.AddTime("kube-started-at", started)
+	}
+
+	// kube.owner.kind, kube.owner.name
+	if kind, name, ok := p.KubeOwner(); ok {
+		pset.AddMap("kube-owner", map[string]string{"kind": kind, "name": name})
+	}
+
+	return pset
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/boz/circumspect/propset"
 	"github.com/sirupsen/logrus"
 
 	"k8s.io/api/core/v1"
@@ -28,39 +29,44 @@ var (
 	pkglog = logrus.StandardLogger().WithField("package", "resolver/kube")
 )
 
-const (
-	// todo: configureable
-	informerSyncDuration = time.Minute
-	kubeNamespace        = "default"
-	queryTimeout         = time.Second
-
-	containerIdPrefix = "docker://"
-)
-
+// RequiredProps identifies a container created by the kubelet.
 type RequiredProps interface {
-	DockerID() string
-	DockerLabels() map[string]string
+	// ContainerID returns the runtime-qualified container ID, as
+	// reported in pod status; e.g. "docker://<id>", "containerd://<id>".
+	ContainerID() string
+
+	// ContainerLabels returns the labels the kubelet set on the container.
+	ContainerLabels() map[string]string
 }
 
 type Service interface {
 	Lookup(context.Context, RequiredProps) (Props, error)
-	Shutdown()
 
-	// todo: Ready() <- chan struct{}
+	// Watch returns a channel that is signalled each time the pod
+	// that the given container belongs to is updated or deleted.
+	// The watch is removed when the given context is cancelled.
+	Watch(context.Context, RequiredProps) <-chan struct{}
+
+	// Ready is closed once the pod cache has synced.
+	Ready() <-chan struct{}
+
+	Shutdown()
 
 	Done() <-chan struct{}
 }
 
-func NewService(ctx context.Context) (Service, error) {
-	ctx, cancel := context.WithCancel(ctx)
+func NewService(ctx context.Context, cfg Config) (Service, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
 
-	client, err := defaultKubeClient()
+	client, err := newKubeClient(cfg.Kubeconfig)
 	if err != nil {
 		return nil, err
 	}
 
 	// ping kube
-	list, err := client.CoreV1().Pods(kubeNamespace).List(metav1.ListOptions{})
+	list, err := client.CoreV1().Pods(cfg.Namespace).List(metav1.ListOptions{})
 	if err != nil {
 		pkglog.WithError(err).Error("can't connect to kubernetes")
 		return nil, err
@@ -68,12 +74,21 @@ func NewService(ctx context.Context) (Service, error) {
 
 	pkglog.WithField("kube-pods", len(list.Items)).Debug("connected to kube")
 
+	ctx, cancel := context.WithCancel(ctx)
+
 	s := &service{
 		client:    client,
+		namespace: cfg.Namespace,
+		timeout:   cfg.QueryTimeout,
 		requestch: make(chan *lookupRequest),
 		reqdonech: make(chan *lookupRequest),
 		requests:  make(map[string][]*lookupRequest),
 		recheckch: make(chan *v1.Pod),
+		deletech:  make(chan string),
+		watchch:   make(chan *podWatch),
+		unwatchch: make(chan *podWatch),
+		watchers:  make(map[string][]*podWatch),
+		readych:   make(chan struct{}),
 		donech:    make(chan struct{}),
 		log:       pkglog,
 		cancel:    cancel,
@@ -83,7 +98,7 @@ func NewService(ctx context.Context) (Service, error) {
 	s.store, s.controller = cache.NewInformer(
 		s.makeListWatch(),
 		&v1.Pod{},
-		informerSyncDuration,
+		cfg.SyncPeriod,
 		s.makeEventHandler(),
 	)
 
@@ -95,12 +110,19 @@ func NewService(ctx context.Context) (Service, error) {
 
 type service struct {
 	client     kubernetes.Interface
+	namespace  string
+	timeout    time.Duration
 	store      cache.Store
 	controller cache.Controller
 	requestch  chan *lookupRequest
 	reqdonech  chan *lookupRequest
 	requests   map[string][]*lookupRequest
 	recheckch  chan *v1.Pod
+	deletech   chan string
+	watchch    chan *podWatch
+	unwatchch  chan *podWatch
+	watchers   map[string][]*podWatch
+	readych    chan struct{}
 	donech     chan struct{}
 	log        logrus.FieldLogger
 	cancel     context.CancelFunc
@@ -122,7 +144,25 @@ type queryParams struct {
 }
 
 func (qp queryParams) key() string {
-	return qp.namespace + "/" + qp.podName
+	return podKey(qp.namespace, qp.podName)
+}
+
+type podWatch struct {
+	key string
+	ch  chan struct{}
+}
+
+// signal notifies the watcher without blocking.  Pending
+// signals are coalesced.
+func (w *podWatch) signal() {
+	select {
+	case w.ch <- struct{}{}:
+	default:
+	}
+}
+
+func podKey(namespace, name string) string {
+	return namespace + "/" + name
 }
 
 func (s *service) Shutdown() {
@@ -134,15 +174,19 @@ func (s *service) Done() <-chan struct{} {
 	return s.donech
 }
 
-func (s *service) Lookup(ctx context.Context, dprops RequiredProps) (Props, error) {
-	log := s.log.WithField("docker-id", dprops.DockerID())
+func (s *service) Ready() <-chan struct{} {
+	return s.readych
+}
+
+func (s *service) Lookup(ctx context.Context, cprops RequiredProps) (Props, error) {
+	log := s.log.WithField("container-id", cprops.ContainerID())
 
 	log.Debug("resolving pod...")
 
-	// extract kube properties from docker labels
-	qp, err := queryParamsFromProps(dprops)
+	// extract kube properties from container labels
+	qp, err := queryParamsFromProps(cprops)
 	if err != nil {
-		log.WithError(err).Info("invalid docker properties")
+		log.WithError(err).Info("invalid container properties")
 		return nil, err
 	}
 
@@ -168,7 +212,7 @@ func (s *service) Lookup(ctx context.Context, dprops RequiredProps) (Props, erro
 		}
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
 	ch := make(chan Props, 1)
@@ -198,6 +242,33 @@ func (s *service) Lookup(ctx context.Context, dprops RequiredProps) (Props, erro
 	}
 }
 
+func (s *service) Watch(ctx context.Context, cprops RequiredProps) <-chan struct{} {
+	qp, err := queryParamsFromProps(cprops)
+	if err != nil {
+		return nil
+	}
+
+	w := &podWatch{qp.key(), make(chan struct{}, 1)}
+
+	select {
+	case <-s.ctx.Done():
+		return nil
+	case <-ctx.Done():
+		return nil
+	case s.watchch <- w:
+	}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-s.ctx.Done():
+		}
+		s.unwatchch <- w
+	}()
+
+	return w.ch
+}
+
 func (s *service) run() {
 	defer close(s.donech)
 	defer s.cancel()
@@ -212,6 +283,13 @@ func (s *service) run() {
 		s.controller.Run(s.ctx.Done())
 	}()
 
+	go func() {
+		if cache.WaitForCacheSync(s.ctx.Done(), s.controller.HasSynced) {
+			log.Debug("ready")
+			close(s.readych)
+		}
+	}()
+
 loop:
 	for {
 		select {
@@ -223,6 +301,13 @@ loop:
 			s.handleRequestDone(req)
 		case pod := <-s.recheckch:
 			s.handleRecheck(pod)
+			s.notifyWatchers(podKey(pod.Namespace, pod.Name))
+		case key := <-s.deletech:
+			s.notifyWatchers(key)
+		case w := <-s.watchch:
+			s.watchers[w.key] = append(s.watchers[w.key], w)
+		case w := <-s.unwatchch:
+			s.handleUnwatch(w)
 		}
 	}
 
@@ -232,6 +317,12 @@ loop:
 		s.handleRequestDone(<-s.reqdonech)
 	}
 
+	log.Debugf("draining watches for %v pods", len(s.watchers))
+
+	for len(s.watchers) > 0 {
+		s.handleUnwatch(<-s.unwatchch)
+	}
+
 	<-cdonech
 }
 
@@ -269,6 +360,36 @@ func (s *service) handleRequestDone(req *lookupRequest) {
 	s.requests[req.qp.key()] = requests
 }
 
+func (s *service) handleUnwatch(w *podWatch) {
+	watchers := s.watchers[w.key]
+
+	for idx, item := range watchers {
+		if item == w {
+			watchers = append(watchers[:idx], watchers[idx+1:]...)
+			break
+		}
+	}
+
+	if len(watchers) == 0 {
+		delete(s.watchers, w.key)
+		return
+	}
+
+	s.watchers[w.key] = watchers
+}
+
+func (s *service) notifyWatchers(key string) {
+	watchers := s.watchers[key]
+
+	if len(watchers) > 0 {
+		s.log.WithField("lookup-key", key).Debugf("notifying %v watchers", len(watchers))
+	}
+
+	for _, w := range watchers {
+		w.signal()
+	}
+}
+
 func (s *service) handleRecheck(pod *v1.Pod) {
 	key := pod.Namespace + "/" + pod.Name
 
@@ -320,11 +441,11 @@ func (s *service) matchQuery(qp queryParams, obj interface{}) (Props, bool, erro
 				log.Debug("empty container id")
 				return nil, false, nil
 
-			case cs.ContainerID != containerIdPrefix+qp.containerID:
+			case cs.ContainerID != qp.containerID:
 
 				log.
 					WithField("kube.container-id", cs.ContainerID).
-					WithField("docker.container-id", qp.containerID).
+					WithField("runtime.container-id", qp.containerID).
 					Warn("mismatched container id")
 				return nil, false, ErrInvalidContainerID
 
@@ -334,7 +455,7 @@ func (s *service) matchQuery(qp queryParams, obj interface{}) (Props, bool, erro
 					WithField("kube-ns", pod.Namespace).
 					WithField("kube-pod", pod.Name).
 					WithField("kube-container", cs.Name).
-					WithField("docker-container", cs.ContainerID).
+					WithField("container-id", cs.ContainerID).
 					Debug("container found")
 
 				return newProps(pod, &cs), true, nil
@@ -355,10 +476,10 @@ func (s *service) makeListWatch() *cache.ListWatch {
 
 	return &cache.ListWatch{
 		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
-			return s.client.CoreV1().Pods(kubeNamespace).List(options)
+			return s.client.CoreV1().Pods(s.namespace).List(options)
 		},
 		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
-			return s.client.CoreV1().Pods(kubeNamespace).Watch(options)
+			return s.client.CoreV1().Pods(s.namespace).Watch(options)
 		},
 	}
 }
@@ -368,15 +489,53 @@ func (s *service) makeEventHandler() cache.ResourceEventHandler {
 		AddFunc: func(obj interface{}) {
 			s.signalRecheck(obj)
 		},
-		UpdateFunc: func(_ interface{}, obj interface{}) {
+		UpdateFunc: func(prev interface{}, obj interface{}) {
+			s.logPodChanges(prev, obj)
 			s.signalRecheck(obj)
 		},
 		DeleteFunc: func(obj interface{}) {
 			// todo: delete requests for this object
+			s.signalDelete(obj)
 		},
 	}
 }
 
+// logPodChanges logs the property changes of each container of an updated
+// pod.  The properties are only built and compared when debug logging is enabled.
+func (s *service) logPodChanges(prevObj interface{}, obj interface{}) {
+	if logrus.GetLevel() < logrus.DebugLevel {
+		return
+	}
+
+	prev, ok := prevObj.(*v1.Pod)
+	if !ok {
+		return
+	}
+	pod, ok := obj.(*v1.Pod)
+	if !ok {
+		return
+	}
+
+	for i := range pod.Status.ContainerStatuses {
+		cs := &pod.Status.ContainerStatuses[i]
+
+		for j := range prev.Status.ContainerStatuses {
+			pcs := &prev.Status.ContainerStatuses[j]
+			if pcs.Name != cs.Name {
+				continue
+			}
+
+			changes := propset.Diff(newProps(prev, pcs).PropSet(), newProps(pod, cs).PropSet())
+			if len(changes) > 0 {
+				s.log.WithField("lookup-key", pod.Namespace+"/"+pod.Name).
+					WithField("kube-container", cs.Name).
+					WithField("changes", changes.String()).
+					Debug("pod changed")
+			}
+		}
+	}
+}
+
 func (s *service) signalRecheck(obj interface{}) {
 	log := s.log.WithField("method", "signalRecheck")
 
@@ -393,8 +552,23 @@ func (s *service) signalRecheck(obj interface{}) {
 	}
 }
 
-func queryParamsFromProps(dprops RequiredProps) (queryParams, error) {
-	labels := dprops.DockerLabels()
+func (s *service) signalDelete(obj interface{}) {
+	log := s.log.WithField("method", "signalDelete")
+
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		log.WithError(err).Warnf("unknown object: %#v", obj)
+		return
+	}
+
+	select {
+	case <-s.ctx.Done():
+	case s.deletech <- key:
+	}
+}
+
+func queryParamsFromProps(cprops RequiredProps) (queryParams, error) {
+	labels := cprops.ContainerLabels()
 	qp := queryParams{}
 
 	if qp.namespace = types.GetPodNamespace(labels); qp.namespace == "" {
@@ -413,28 +587,31 @@ func queryParamsFromProps(dprops RequiredProps) (queryParams, error) {
 		return qp, ErrContainerNotRecognized
 	}
 
-	if qp.containerID = dprops.DockerID(); qp.containerID == "" {
+	if qp.containerID = cprops.ContainerID(); qp.containerID == "" {
 		return qp, ErrContainerNotRecognized
 	}
 
 	return qp, nil
 }
 
-func defaultKubeClient() (kubernetes.Interface, error) {
-	config, err := defaultKubeConfig()
+func newKubeClient(kubeconfig string) (kubernetes.Interface, error) {
+	config, err := newKubeConfig(kubeconfig)
 	if err != nil {
 		return nil, err
 	}
 	return kubernetes.NewForConfig(config)
 }
 
-func defaultKubeConfig() (*rest.Config, error) {
+func newKubeConfig(kubeconfig string) (*rest.Config, error) {
+	if kubeconfig != "" {
+		return clientcmd.BuildConfigFromFlags("", kubeconfig)
+	}
+
 	config, err := rest.InClusterConfig()
 	if err == nil {
 		return config, nil
 	}
 
-	// todo: add -f kubeconfig
 	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
 		clientcmd.NewDefaultClientConfigLoadingRules(),
 		&clientcmd.ConfigOverrides{},
@@ -0,0 +1,145 @@
+package podman
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// libpod API prefix.  Podman serves all versions up to its own.
+const apiPrefix = "/v2.0.0/libpod"
+
+// Container is a container as listed by the libpod API.
+type Container struct {
+	ID      string            `json:"Id"`
+	Names   []string          `json:"Names"`
+	Image   string            `json:"Image"`
+	Labels  map[string]string `json:"Labels"`
+	Pid     int               `json:"Pid"`
+	Pod     string            `json:"Pod"`
+	PodName string            `json:"PodName"`
+	State   string            `json:"State"`
+
+	// Whether podman is running rootless.  Set by the Lister.
+	Rootless bool `json:"-"`
+
+	// UID owning the container's user namespace, or -1 if
+	// unknown.  Set by the Lister.
+	UsernsOwner int `json:"-"`
+}
+
+type info struct {
+	Host struct {
+		Security struct {
+			Rootless bool `json:"rootless"`
+		} `json:"security"`
+	} `json:"host"`
+	Version struct {
+		Version string `json:"Version"`
+	} `json:"version"`
+}
+
+// event is a container event, in the docker-compatible format
+// used by the libpod events endpoint.
+type event struct {
+	Type   string `json:"Type"`
+	Action string `json:"Action"`
+	Actor  struct {
+		ID string `json:"ID"`
+	} `json:"Actor"`
+}
+
+// client calls the libpod REST API over a unix socket.
+type client struct {
+	http *http.Client
+}
+
+func newClient(endpoint string) *client {
+	path := strings.TrimPrefix(endpoint, "unix://")
+
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", path)
+		},
+	}
+
+	return &client{&http.Client{Transport: transport}}
+}
+
+func (c *client) Info(ctx context.Context) (*info, error) {
+	out := &info{}
+	if err := c.getJSON(ctx, "/info", nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *client) ListContainers(ctx context.Context) ([]Container, error) {
+	query := url.Values{}
+	query.Set("pod", "true")
+
+	var out []Container
+	if err := c.getJSON(ctx, "/containers/json", query, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Events returns a stream of JSON encoded container events.
+func (c *client) Events(ctx context.Context, actions []string) (io.ReadCloser, error) {
+	filters, err := json.Marshal(map[string][]string{
+		"type":  {"container"},
+		"event": actions,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Set("stream", "true")
+	query.Set("filters", string(filters))
+
+	return c.get(ctx, "/events", query)
+}
+
+func (c *client) getJSON(ctx context.Context, path string, query url.Values, out interface{}) error {
+	body, err := c.get(ctx, path, query)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	return json.NewDecoder(body).Decode(out)
+}
+
+func (c *client) get(ctx context.Context, path string, query url.Values) (io.ReadCloser, error) {
+	u := url.URL{Scheme: "http", Host: "podman", Path: apiPrefix + path, RawQuery: query.Encode()}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req.WithContext(ctx))
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("%v: %v: %s", path, resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	return resp.Body, nil
+}
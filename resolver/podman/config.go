@@ -0,0 +1,59 @@
+package podman
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	defaultEndpoint      = "/run/podman/podman.sock"
+	defaultLookupTimeout = time.Second
+	defaultPeriod        = 10 * time.Second
+	defaultTimeout       = 5 * time.Second
+)
+
+// Config configures the podman resolver.
+type Config struct {
+	// Path of the podman API socket.  Rootless podman serves the API at
+	// $XDG_RUNTIME_DIR/podman/podman.sock; e.g. /run/user/1000/podman/podman.sock.
+	Endpoint string `yaml:"endpoint"`
+
+	// How long a lookup waits for the container of a PID to be found.
+	LookupTimeout time.Duration `yaml:"lookup-timeout"`
+
+	// How often the full list of containers is fetched.
+	ListPeriod time.Duration `yaml:"list-period"`
+
+	// Timeout for requests to podman.
+	RequestTimeout time.Duration `yaml:"request-timeout"`
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Endpoint:       defaultEndpoint,
+		LookupTimeout:  defaultLookupTimeout,
+		ListPeriod:     defaultPeriod,
+		RequestTimeout: defaultTimeout,
+	}
+}
+
+func (c Config) Validate() error {
+	if c.Endpoint == "" {
+		return errors.New("endpoint: required")
+	}
+
+	if c.LookupTimeout <= 0 {
+		return fmt.Errorf("lookup-timeout: must be positive (got %v)", c.LookupTimeout)
+	}
+
+	if c.ListPeriod <= 0 {
+		return fmt.Errorf("list-period: must be positive (got %v)", c.ListPeriod)
+	}
+
+	if c.RequestTimeout <= 0 {
+		return fmt.Errorf("request-timeout: must be positive (got %v)", c.RequestTimeout)
+	}
+
+	return nil
+}
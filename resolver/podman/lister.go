@@ -0,0 +1,219 @@
+package podman
+
+import (
+	"context"
+	"time"
+
+	"github.com/boz/circumspect/resolver/registry"
+	"github.com/sirupsen/logrus"
+)
+
+// Lister periodically fetches the complete list of running
+// containers and sends them to the `Containers()` channel.
+//
+// Unlike the docker resolver, the libpod list includes everything that
+// is needed about a container, so the list is refreshed when
+// the Watcher sees a container start or stop.
+type Lister interface {
+	Containers() <-chan []Container
+
+	// Refresh fetches the list as soon as possible.
+	Refresh()
+
+	Shutdown()
+	Done() <-chan struct{}
+}
+
+func NewLister(ctx context.Context, client *client, rootless bool, period time.Duration, timeout time.Duration) Lister {
+	log := pkglog.WithField("component", "lister")
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	lister := &lister{
+		client:    client,
+		rootless:  rootless,
+		period:    period,
+		timeout:   timeout,
+		outch:     make(chan []Container),
+		refreshch: make(chan struct{}, 1),
+		donech:    make(chan struct{}),
+		log:       log,
+		cancel:    cancel,
+		ctx:       ctx,
+	}
+
+	go lister.run()
+
+	return lister
+}
+
+type lister struct {
+	client   *client
+	rootless bool
+	period   time.Duration
+	timeout  time.Duration
+
+	outch     chan []Container
+	refreshch chan struct{}
+
+	err    error
+	donech chan struct{}
+	log    logrus.FieldLogger
+	cancel context.CancelFunc
+	ctx    context.Context
+}
+
+func (l *lister) Containers() <-chan []Container {
+	return l.outch
+}
+
+func (l *lister) Refresh() {
+	select {
+	case l.refreshch <- struct{}{}:
+	default:
+	}
+}
+
+func (l *lister) Shutdown() {
+	l.cancel()
+	<-l.donech
+}
+
+func (l *lister) Done() <-chan struct{} {
+	return l.donech
+}
+
+func (l *lister) run() {
+	defer close(l.donech)
+	defer l.cancel()
+	defer l.log.Debug("done")
+
+	var ticker *time.Timer
+	var tickch <-chan time.Time
+
+	var containers []Container
+	var outch chan []Container
+
+	// a refresh was requested while the runner was active.
+	var pending bool
+
+	runner := newListRunner(l.ctx, l.client, l.rootless, l.timeout)
+	runnerch := runner.Done()
+
+loop:
+
+	for {
+
+		select {
+
+		case <-l.ctx.Done():
+			break loop
+
+		case <-runnerch:
+			if err := runner.Err(); err != nil {
+				l.log.WithError(err).Error("runner failed")
+				l.err = err
+				break loop
+			}
+
+			containers = runner.Result().([]Container)
+
+			l.log.Debugf("list complete: %v containers found", len(containers))
+
+			// an empty list is delivered too: it removes
+			// exited containers from the registry.
+			outch = l.outch
+
+			runner = nil
+			runnerch = nil
+
+			if pending {
+				pending = false
+				l.log.Debug("starting runner")
+				runner = newListRunner(l.ctx, l.client, l.rootless, l.timeout)
+				runnerch = runner.Done()
+				continue
+			}
+
+			ticker = time.NewTimer(l.period)
+			tickch = ticker.C
+
+		case <-l.refreshch:
+			if runner != nil {
+				pending = true
+				continue
+			}
+
+			if ticker != nil {
+				ticker.Stop()
+			}
+			tickch = nil
+
+			l.log.Debug("starting runner")
+			runner = newListRunner(l.ctx, l.client, l.rootless, l.timeout)
+			runnerch = runner.Done()
+
+		case <-tickch:
+			tickch = nil
+
+			l.log.Debug("starting runner")
+			runner = newListRunner(l.ctx, l.client, l.rootless, l.timeout)
+			runnerch = runner.Done()
+
+		case outch <- containers:
+			l.log.Debugf("%v containers delivered", len(containers))
+
+			containers = nil
+			outch = nil
+
+		}
+
+	}
+
+	if ticker != nil {
+		ticker.Stop()
+	}
+
+	if runner != nil {
+		l.log.Debug("draining runner")
+		<-runner.Done()
+		l.err = runner.Err()
+	}
+}
+
+func newListRunner(ctx context.Context, client *client, rootless bool, timeout time.Duration) registry.Runner {
+	return registry.NewRunner(ctx, timeout, func(ctx context.Context) (interface{}, error) {
+		containers, err := client.ListContainers(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return filterContainers(containers, rootless), nil
+	})
+}
+
+func filterContainers(containers []Container, rootless bool) []Container {
+	filtered := []Container{}
+
+	for _, c := range containers {
+		if !acceptContainer(c) {
+			continue
+		}
+
+		c.Rootless = rootless
+
+		owner, err := usernsOwner(c.Pid)
+		if err != nil {
+			pkglog.WithField("podman-id", c.ID).
+				WithError(err).Debug("can't read user namespace owner")
+		}
+		c.UsernsOwner = owner
+
+		filtered = append(filtered, c)
+	}
+
+	return filtered
+}
+
+func acceptContainer(c Container) bool {
+	return c.State == "running" && c.Pid > 0
+}
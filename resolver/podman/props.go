@@ -0,0 +1,82 @@
+package podman
+
+import "github.com/boz/circumspect/propset"
+
+type Props interface {
+	PodmanID() string
+	PodmanName() string
+	PodmanImage() string
+	PodmanLabels() map[string]string
+	PodmanPid() int
+	PodmanPodID() string
+	PodmanPodName() string
+	PodmanRootless() bool
+
+	// PodmanUsernsOwner returns the UID owning the container's
+	// user namespace, or -1 if it is not known.
+	PodmanUsernsOwner() int
+
+	PropSet() propset.PropSet
+}
+
+type makeProps Container
+
+func (p makeProps) PodmanID() string {
+	return p.ID
+}
+
+func (p makeProps) PodmanName() string {
+	if len(p.Names) == 0 {
+		return ""
+	}
+	return p.Names[0]
+}
+
+func (p makeProps) PodmanImage() string {
+	return p.Image
+}
+
+func (p makeProps) PodmanLabels() map[string]string {
+	return p.Labels
+}
+
+func (p makeProps) PodmanPid() int {
+	return p.Pid
+}
+
+func (p makeProps) PodmanPodID() string {
+	return p.Pod
+}
+
+func (p makeProps) PodmanPodName() string {
+	return p.PodName
+}
+
+func (p makeProps) PodmanRootless() bool {
+	return p.Rootless
+}
+
+func (p makeProps) PodmanUsernsOwner() int {
+	return p.UsernsOwner
+}
+
+func (p makeProps) PropSet() propset.PropSet {
+	pset := propset.New().
+		AddString("podman-id", p.PodmanID()).
+		AddString("podman-name", p.PodmanName()).
+		AddString("podman-image", p.PodmanImage()).
+		AddMap("podman-labels", p.PodmanLabels()).
+		AddInt("podman-pid", p.PodmanPid()).
+		AddBool("podman-rootless", p.PodmanRootless())
+
+	if p.PodmanPodID() != "" {
+		pset.AddString("podman-pod-id", p.PodmanPodID()).
+			AddString("podman-pod-name", p.PodmanPodName())
+	}
+
+	if owner := p.PodmanUsernsOwner(); owner >= 0 {
+		pset.AddInt("podman-userns-owner", owner)
+	}
+
+	return pset
+}
@@ -0,0 +1,73 @@
+package podman
+
+import (
+	"context"
+	"time"
+
+	"github.com/boz/circumspect/resolver/cgroup"
+	"github.com/boz/circumspect/resolver/registry"
+)
+
+var (
+	ErrInvalidPid = registry.ErrInvalidPid
+	ErrNotFound   = registry.ErrNotFound
+	ErrNotRunning = registry.ErrNotRunning
+)
+
+// Registry contains a set of running containers and
+// allows for finding which container a PID belongs to, if any.
+type Registry interface {
+
+	// Lookup will try to find the container that is running the given PID.
+	// See registry.Registry.
+	Lookup(ctx context.Context, pid int) (Props, error)
+
+	// Sync replaces the set of running containers.
+	Sync([]Container) error
+
+	// Watch returns a channel that is signalled each time the container with
+	// the given id changes or is no longer running.
+	// The watch is removed when the given context is cancelled.
+	Watch(ctx context.Context, id string) <-chan struct{}
+
+	Shutdown()
+	Done() <-chan struct{}
+}
+
+type containerRegistry struct {
+	registry.Registry
+}
+
+func NewRegistry(ctx context.Context, lookupTimeout time.Duration) Registry {
+	return containerRegistry{registry.New(ctx, registry.Config{
+		Runtime:        "podman",
+		CgroupRuntimes: []string{cgroup.RuntimePodman},
+		LookupTimeout:  lookupTimeout,
+		Log:            pkglog.WithField("component", "registry"),
+	})}
+}
+
+func (r containerRegistry) Lookup(ctx context.Context, pid int) (Props, error) {
+	c, err := r.Registry.Lookup(ctx, pid)
+	if err != nil {
+		return nil, err
+	}
+	return makeProps(c.Value.(Container)), nil
+}
+
+func (r containerRegistry) Sync(containers []Container) error {
+	items := make([]registry.Container, 0, len(containers))
+	for _, c := range containers {
+		items = append(items, registry.Container{
+			Key:   c.ID,
+			ID:    c.ID,
+			Pid:   c.Pid,
+			Value: c,
+		})
+	}
+	return r.Registry.Sync(items)
+}
+
+func (r containerRegistry) Watch(ctx context.Context, id string) <-chan struct{} {
+	return r.Registry.Watch(ctx, id)
+}
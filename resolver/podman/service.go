@@ -0,0 +1,165 @@
+package podman
+
+import (
+	"context"
+
+	"github.com/boz/circumspect/resolver/registry"
+	"github.com/sirupsen/logrus"
+)
+
+var pkglog = logrus.StandardLogger().WithField("package", "resolver/podman")
+
+type RequiredProps interface {
+	Pid() int
+}
+
+// Service maintains a set of running podman containers and
+// a registry for searching for containers by PID.
+//
+// Lister periodically scans all running containers, including their pods.
+// Watcher listens to podman events and triggers a new scan when
+// containers start or stop.
+type Service interface {
+	Lookup(context.Context, RequiredProps) (Props, error)
+
+	// Watch signals each time the container with the given id changes
+	// or stops. See Registry.Watch.
+	Watch(ctx context.Context, id string) <-chan struct{}
+
+	// Ready is closed once the first list of running containers has been received.
+	Ready() <-chan struct{}
+
+	Shutdown()
+	Done() <-chan struct{}
+}
+
+func NewService(ctx context.Context, cfg Config) (Service, error) {
+	log := pkglog.WithField("component", "service")
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	client := newClient(cfg.Endpoint)
+
+	ictx, icancel := context.WithTimeout(ctx, cfg.RequestTimeout)
+	defer icancel()
+
+	info, err := client.Info(ictx)
+	if err != nil {
+		log.WithError(err).Errorf("can't connect to podman at %v", cfg.Endpoint)
+		return nil, err
+	}
+
+	log.WithField("podman-version", info.Version.Version).
+		WithField("rootless", info.Host.Security.Rootless).
+		Debug("connected to podman")
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	lister := NewLister(ctx, client, info.Host.Security.Rootless, cfg.ListPeriod, cfg.RequestTimeout)
+	watcher := NewWatcher(ctx, client)
+	reg := NewRegistry(ctx, cfg.LookupTimeout)
+
+	svc := &service{
+		lister:   lister,
+		watcher:  watcher,
+		registry: reg,
+
+		log:     log,
+		cancel:  cancel,
+		ctx:     ctx,
+		readych: make(chan struct{}),
+		donech:  make(chan struct{}),
+	}
+
+	go svc.run()
+
+	return svc, nil
+}
+
+type service struct {
+	lister   Lister
+	watcher  registry.Watcher
+	registry Registry
+
+	log     logrus.FieldLogger
+	readych chan struct{}
+	donech  chan struct{}
+	cancel  context.CancelFunc
+	ctx     context.Context
+}
+
+func (s *service) Lookup(ctx context.Context, pprops RequiredProps) (Props, error) {
+	return s.registry.Lookup(ctx, pprops.Pid())
+}
+
+func (s *service) Watch(ctx context.Context, id string) <-chan struct{} {
+	return s.registry.Watch(ctx, id)
+}
+
+func (s *service) Ready() <-chan struct{} {
+	return s.readych
+}
+
+func (s *service) Shutdown() {
+	s.cancel()
+	<-s.donech
+}
+
+func (s *service) Done() <-chan struct{} {
+	return s.donech
+}
+
+func (s *service) run() {
+	defer close(s.donech)
+	defer s.log.Debug("done")
+
+loop:
+	for {
+		select {
+
+		case <-s.ctx.Done():
+			s.log.Debug("context cancelled")
+			break loop
+
+		case <-s.lister.Done():
+			s.log.Debug("early lister completion")
+			break loop
+
+		case <-s.watcher.Done():
+			s.log.Debug("early watcher completion")
+			break loop
+
+		case <-s.registry.Done():
+			s.log.Debug("early registry completion")
+			break loop
+
+		case containers := <-s.lister.Containers():
+			s.log.Debugf("updating with %v containers", len(containers))
+			s.registry.Sync(containers)
+			s.markReady()
+
+		case event := <-s.watcher.Events():
+			s.log.WithField("podman-id", event.Key).
+				WithField("event-type", event.Type).
+				Debug("watcher event received")
+			s.lister.Refresh()
+		}
+	}
+
+	s.cancel()
+
+	<-s.lister.Done()
+	<-s.watcher.Done()
+	<-s.registry.Done()
+}
+
+func (s *service) markReady() {
+	select {
+	case <-s.readych:
+	default:
+		s.log.Debug("ready")
+		close(s.readych)
+	}
+}
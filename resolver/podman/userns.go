@@ -0,0 +1,30 @@
+package podman
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// ioctl(2) request returning the owner UID of a user namespace (linux 4.11).
+const nsGetOwnerUID = 0xb704
+
+// usernsOwner returns the UID of the user that created the user namespace
+// of the given process.  This is the user running rootless podman, or 0.
+func usernsOwner(pid int) (int, error) {
+	file, err := os.Open(fmt.Sprintf("/proc/%v/ns/user", pid))
+	if err != nil {
+		return -1, err
+	}
+	defer file.Close()
+
+	var uid uint32
+
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, file.Fd(), nsGetOwnerUID, uintptr(unsafe.Pointer(&uid)))
+	if errno != 0 {
+		return -1, errno
+	}
+
+	return int(uid), nil
+}
@@ -0,0 +1,59 @@
+package podman
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/boz/circumspect/resolver/registry"
+)
+
+// watcherActions maps the container events that are watched to the
+// resulting event type.  Podman reports "died" rather than docker's "die".
+var watcherActions = map[string]registry.EventType{
+	"start":   registry.EventTypeCreate,
+	"pause":   registry.EventTypeUpdate,
+	"unpause": registry.EventTypeUpdate,
+	"died":    registry.EventTypeDelete,
+	"remove":  registry.EventTypeDelete,
+}
+
+// NewWatcher returns a Watcher for container lifecycle events in podman.
+func NewWatcher(ctx context.Context, client *client) registry.Watcher {
+	var actions []string
+	for action := range watcherActions {
+		actions = append(actions, action)
+	}
+
+	return registry.NewWatcher(ctx, func(ctx context.Context) (registry.EventStream, error) {
+		body, err := client.Events(ctx, actions)
+		if err != nil {
+			return nil, err
+		}
+		return &eventStream{body, json.NewDecoder(body)}, nil
+	}, pkglog)
+}
+
+type eventStream struct {
+	body    io.ReadCloser
+	decoder *json.Decoder
+}
+
+func (s *eventStream) Next() (registry.WatchEvent, bool, error) {
+	var ev event
+
+	if err := s.decoder.Decode(&ev); err != nil {
+		return registry.WatchEvent{}, false, err
+	}
+
+	etype, ok := watcherActions[ev.Action]
+	if !ok || ev.Type != "container" || ev.Actor.ID == "" {
+		return registry.WatchEvent{}, false, nil
+	}
+
+	return registry.WatchEvent{Type: etype, Key: ev.Actor.ID}, true, nil
+}
+
+func (s *eventStream) Close() error {
+	return s.body.Close()
+}
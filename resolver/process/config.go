@@ -0,0 +1,29 @@
+package process
+
+import "fmt"
+
+const defaultHashCacheSize = 1024
+
+// Config configures the process resolver.
+type Config struct {
+	// Compute the SHA-256 of each process's executable.
+	HashExe bool `yaml:"hash-exe"`
+
+	// Number of executable hashes to cache.  Hashes are keyed by
+	// device, inode, size and modification time of the executable.
+	HashCacheSize int `yaml:"hash-cache-size"`
+}
+
+func DefaultConfig() Config {
+	return Config{
+		HashExe:       true,
+		HashCacheSize: defaultHashCacheSize,
+	}
+}
+
+func (c Config) Validate() error {
+	if c.HashExe && c.HashCacheSize <= 0 {
+		return fmt.Errorf("hash-cache-size: must be positive (got %v)", c.HashCacheSize)
+	}
+	return nil
+}
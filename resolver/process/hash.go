@@ -0,0 +1,56 @@
+package process
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+
+	lru "github.com/hashicorp/golang-lru"
+)
+
+// hasher computes the SHA-256 of process executables.  Hashes are
+// cached by the identity of the executable file, where the platform
+// can identify it; see fileHashKey.
+type hasher struct {
+	cache *lru.Cache
+}
+
+func newHasher(size int) (*hasher, error) {
+	cache, err := lru.New(size)
+	if err != nil {
+		return nil, err
+	}
+	return &hasher{cache}, nil
+}
+
+// Hash returns the hex encoded SHA-256 of the executable of the
+// given process.  The executable is read through /proc/<pid>/exe
+// so that it is found even if it has been replaced or deleted.
+func (h *hasher) Hash(pid int) (string, error) {
+	file, err := os.Open(procPath(pid, "exe"))
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	key, cacheable := fileHashKey(file)
+
+	if sum, ok := h.cache.Get(key); cacheable && ok {
+		return sum.(string), nil
+	}
+
+	digest := sha256.New()
+	if _, err := io.Copy(digest, file); err != nil {
+		return "", err
+	}
+
+	sum := hex.EncodeToString(digest.Sum(nil))
+
+	// don't cache the hash if the file changed while it was read.
+	if after, ok := fileHashKey(file); cacheable && ok && after == key {
+		h.cache.Add(key, sum)
+	}
+
+	return sum, nil
+}
@@ -0,0 +1,15 @@
+// +build !linux
+
+package process
+
+import "os"
+
+// hashKey identifies the content of an executable.  The change time
+// that makes the key safe is only read on linux, so elsewhere hashes
+// are never cached.
+type hashKey struct{}
+
+// fileHashKey returns the key of file, and false if its hash can't be cached.
+func fileHashKey(file *os.File) (hashKey, bool) {
+	return hashKey{}, false
+}
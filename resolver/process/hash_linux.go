@@ -0,0 +1,28 @@
+// +build linux
+
+package process
+
+import (
+	"os"
+	"syscall"
+)
+
+// hashKey identifies the content of an executable.  It includes the
+// change time, which unlike the modification time can't be set by the
+// file's owner, so a rewritten executable is always hashed again.
+type hashKey struct {
+	dev   uint64
+	ino   uint64
+	size  int64
+	mtime syscall.Timespec
+	ctime syscall.Timespec
+}
+
+// fileHashKey returns the key of file, and false if its hash can't be cached.
+func fileHashKey(file *os.File) (hashKey, bool) {
+	var st syscall.Stat_t
+	if err := syscall.Fstat(int(file.Fd()), &st); err != nil {
+		return hashKey{}, false
+	}
+	return hashKey{uint64(st.Dev), st.Ino, st.Size, st.Mtim, st.Ctim}, true
+}
@@ -0,0 +1,208 @@
+package process
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/boz/circumspect/resolver/uds"
+)
+
+// Namespaces whose inodes are read from /proc/<pid>/ns.
+var namespaces = []string{"pid", "mnt", "net", "user", "cgroup"}
+
+// Info is the state of a process read from /proc.  Values that
+// could not be read are left empty.
+type Info struct {
+	Pid  int
+	Exe  string
+	Args []string
+
+	StartTime time.Time
+
+	// real, effective, saved set and filesystem ids.
+	Uids [4]int
+	Gids [4]int
+
+	Groups []int
+
+	// capability sets, as hex masks.
+	CapEff string
+	CapPrm string
+	CapBnd string
+
+	NoNewPrivs bool
+
+	// 0: disabled, 1: strict, 2: filter
+	Seccomp int
+
+	// namespace inodes, by namespace name.
+	Namespaces map[string]uint64
+}
+
+// procPath returns the path of a file in /proc/<pid>.
+func procPath(pid int, name string) string {
+	return fmt.Sprintf("/proc/%v/%v", pid, name)
+}
+
+// readInfo reads the state of the given process.  An error is returned
+// if the process doesn't exist; files that require elevated privileges
+// (exe, ns/*) are skipped if they can't be read.
+func readInfo(pid int) (Info, error) {
+	info := Info{Pid: pid}
+
+	if err := info.readStatus(); err != nil {
+		return info, err
+	}
+
+	if err := info.readStat(); err != nil {
+		return info, err
+	}
+
+	if err := info.readCmdline(); err != nil {
+		return info, err
+	}
+
+	if exe, err := os.Readlink(procPath(pid, "exe")); err == nil {
+		info.Exe = exe
+	}
+
+	info.Namespaces = make(map[string]uint64)
+	for _, ns := range namespaces {
+		if inode, err := namespaceInode(pid, ns); err == nil {
+			info.Namespaces[ns] = inode
+		}
+	}
+
+	return info, nil
+}
+
+func (info *Info) readStatus() error {
+	file, err := os.Open(procPath(info.Pid, "status"))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		parts := strings.SplitN(scanner.Text(), ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		value := strings.TrimSpace(parts[1])
+
+		switch parts[0] {
+		case "Uid":
+			err = parseIds(value, info.Uids[:])
+		case "Gid":
+			err = parseIds(value, info.Gids[:])
+		case "Groups":
+			info.Groups, err = parseInts(value)
+		case "CapEff":
+			info.CapEff = value
+		case "CapPrm":
+			info.CapPrm = value
+		case "CapBnd":
+			info.CapBnd = value
+		case "NoNewPrivs":
+			info.NoNewPrivs = value == "1"
+		case "Seccomp":
+			info.Seccomp, err = strconv.Atoi(value)
+		}
+
+		if err != nil {
+			return fmt.Errorf("status: %v: %v", parts[0], err)
+		}
+	}
+
+	return scanner.Err()
+}
+
+// readStat reads the start time of the process.
+func (info *Info) readStat() error {
+	buf, err := ioutil.ReadFile(procPath(info.Pid, "stat"))
+	if err != nil {
+		return err
+	}
+
+	// the command name (field 2) may contain spaces and parentheses.
+	stat := string(buf)
+	idx := strings.LastIndex(stat, ")")
+	if idx < 0 {
+		return fmt.Errorf("stat: malformed")
+	}
+
+	// fields following the command name, starting with state (field 3).
+	fields := strings.Fields(stat[idx+1:])
+	if len(fields) < 20 {
+		return fmt.Errorf("stat: malformed")
+	}
+
+	// field 22: start time, in clock ticks after boot.
+	ticks, err := strconv.ParseUint(fields[19], 10, 64)
+	if err != nil {
+		return fmt.Errorf("stat: start time: %v", err)
+	}
+
+	info.StartTime, err = uds.StartTime(ticks)
+
+	return err
+}
+
+func (info *Info) readCmdline() error {
+	buf, err := ioutil.ReadFile(procPath(info.Pid, "cmdline"))
+	if err != nil {
+		return err
+	}
+
+	// kernel threads have no arguments.
+	if len(buf) > 0 {
+		info.Args = strings.Split(strings.TrimSuffix(string(buf), "\x00"), "\x00")
+	}
+
+	return nil
+}
+
+// namespaceInode returns the inode number identifying the given
+// namespace of a process, as found in a link such as "net:[4026531992]".
+func namespaceInode(pid int, ns string) (uint64, error) {
+	link, err := os.Readlink(procPath(pid, "ns/"+ns))
+	if err != nil {
+		return 0, err
+	}
+
+	value := strings.TrimSuffix(strings.TrimPrefix(link, ns+":["), "]")
+
+	return strconv.ParseUint(value, 10, 64)
+}
+
+func parseIds(value string, ids []int) error {
+	values, err := parseInts(value)
+	if err != nil {
+		return err
+	}
+	if len(values) != len(ids) {
+		return fmt.Errorf("expected %v ids (got %v)", len(ids), len(values))
+	}
+	copy(ids, values)
+	return nil
+}
+
+func parseInts(value string) ([]int, error) {
+	var ints []int
+	for _, field := range strings.Fields(value) {
+		i, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, err
+		}
+		ints = append(ints, i)
+	}
+	return ints, nil
+}
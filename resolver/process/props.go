@@ -0,0 +1,73 @@
+package process
+
+import (
+	"strconv"
+
+	"github.com/boz/circumspect/propset"
+)
+
+type Props interface {
+	Info() Info
+
+	// ExeSHA256 returns the hex encoded SHA-256 of the executable, or
+	// the empty string if hashing is disabled or it couldn't be read.
+	ExeSHA256() string
+
+	PropSet() propset.PropSet
+}
+
+var seccompModes = map[int]string{
+	0: "disabled",
+	1: "strict",
+	2: "filter",
+}
+
+type props struct {
+	info Info
+	hash string
+}
+
+func (p props) Info() Info {
+	return p.info
+}
+
+func (p props) ExeSHA256() string {
+	return p.hash
+}
+
+func (p props) PropSet() propset.PropSet {
+	info := p.info
+
+	pset := propset.New().
+		AddStrings("process-args", info.Args).
+		AddTime("process-start-time", info.StartTime).
+		AddInt("process-uid-real", info.Uids[0]).
+		AddInt("process-uid-effective", info.Uids[1]).
+		AddInt("process-uid-saved", info.Uids[2]).
+		AddInt("process-gid-real", info.Gids[0]).
+		AddInt("process-gid-effective", info.Gids[1]).
+		AddInt("process-gid-saved", info.Gids[2]).
+		AddString("process-cap-effective", info.CapEff).
+		AddString("process-cap-permitted", info.CapPrm).
+		AddString("process-cap-bounding", info.CapBnd).
+		AddBool("process-no-new-privs", info.NoNewPrivs).
+		AddString("process-seccomp", seccompModes[info.Seccomp])
+
+	if len(info.Groups) > 0 {
+		pset.AddInts("process-groups", info.Groups)
+	}
+
+	if info.Exe != "" {
+		pset.AddString("process-exe", info.Exe)
+	}
+
+	if p.hash != "" {
+		pset.AddString("process-exe-sha256", p.hash)
+	}
+
+	for ns, inode := range info.Namespaces {
+		pset.AddString("process-ns-"+ns, strconv.FormatUint(inode, 10))
+	}
+
+	return pset
+}
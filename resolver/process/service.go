@@ -0,0 +1,117 @@
+package process
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrInvalidPid = errors.New("invalid PID")
+
+	pkglog = logrus.StandardLogger().WithField("package", "resolver/process")
+)
+
+type RequiredProps interface {
+	Pid() int
+}
+
+// Service reads the executable, credentials, capabilities, security
+// settings and namespaces of a process from /proc.
+type Service interface {
+	Lookup(context.Context, RequiredProps) (Props, error)
+
+	// Ready is closed immediately; there is no state to load.
+	Ready() <-chan struct{}
+
+	Shutdown()
+	Done() <-chan struct{}
+}
+
+func NewService(ctx context.Context, cfg Config) (Service, error) {
+	log := pkglog.WithField("component", "service")
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	var h *hasher
+
+	if cfg.HashExe {
+		var err error
+		if h, err = newHasher(cfg.HashCacheSize); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	s := &service{
+		hasher:  h,
+		readych: make(chan struct{}),
+		donech:  make(chan struct{}),
+		log:     log,
+		cancel:  cancel,
+		ctx:     ctx,
+	}
+
+	close(s.readych)
+
+	go s.run()
+
+	return s, nil
+}
+
+type service struct {
+	hasher  *hasher
+	readych chan struct{}
+	donech  chan struct{}
+	log     logrus.FieldLogger
+	cancel  context.CancelFunc
+	ctx     context.Context
+}
+
+func (s *service) Lookup(ctx context.Context, pprops RequiredProps) (Props, error) {
+	log := s.log.WithField("pid", pprops.Pid())
+
+	info, err := readInfo(pprops.Pid())
+	switch {
+	case os.IsNotExist(err):
+		return nil, ErrInvalidPid
+	case err != nil:
+		log.WithError(err).Warn("reading process")
+		return nil, err
+	}
+
+	p := props{info: info}
+
+	if s.hasher != nil && info.Exe != "" {
+		if p.hash, err = s.hasher.Hash(info.Pid); err != nil {
+			log.WithError(err).Debugf("hashing %v", info.Exe)
+		}
+	}
+
+	return p, nil
+}
+
+func (s *service) Ready() <-chan struct{} {
+	return s.readych
+}
+
+func (s *service) Shutdown() {
+	s.cancel()
+	<-s.donech
+}
+
+func (s *service) Done() <-chan struct{} {
+	return s.donech
+}
+
+func (s *service) run() {
+	defer close(s.donech)
+	defer s.log.Debug("done")
+
+	<-s.ctx.Done()
+}
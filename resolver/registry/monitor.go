@@ -0,0 +1,152 @@
+package registry
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Monitor fetches the state of a single container each time it is
+// refreshed and submits it to a registry.
+type Monitor interface {
+	Key() interface{}
+	Refresh() error
+	Shutdown()
+	Done() <-chan struct{}
+}
+
+// MonitorConfig configures a Monitor for a container runtime.
+type MonitorConfig struct {
+	// Key of the container; see Container.Key.
+	Key interface{}
+
+	// Fetch returns the current state of the container.
+	Fetch Operation
+
+	// Submit is called with each state returned by Fetch.
+	Submit func(interface{}) error
+
+	// Gone, if set, is true for errors from Fetch that mean the
+	// container is no longer running.  The Monitor then shuts down.
+	Gone func(error) bool
+
+	Timeout time.Duration
+
+	Log logrus.FieldLogger
+}
+
+// NewMonitor returns a Monitor that fetches the state of the container
+// immediately, and again each time it is refreshed.
+func NewMonitor(ctx context.Context, cfg MonitorConfig) Monitor {
+	ctx, cancel := context.WithCancel(ctx)
+
+	m := &monitor{
+		cfg:       cfg,
+		refreshch: make(chan struct{}),
+		donech:    make(chan struct{}),
+		log:       cfg.Log.WithField("component", "container"),
+		cancel:    cancel,
+		ctx:       ctx,
+	}
+
+	go m.run()
+
+	return m
+}
+
+type monitor struct {
+	cfg       MonitorConfig
+	refreshch chan struct{}
+	donech    chan struct{}
+	log       logrus.FieldLogger
+	cancel    context.CancelFunc
+	ctx       context.Context
+}
+
+func (m *monitor) Key() interface{} {
+	return m.cfg.Key
+}
+
+func (m *monitor) Refresh() error {
+	select {
+	case m.refreshch <- struct{}{}:
+		return nil
+	case <-m.ctx.Done():
+		return ErrNotRunning
+	}
+}
+
+func (m *monitor) Shutdown() {
+	m.cancel()
+}
+
+func (m *monitor) Done() <-chan struct{} {
+	return m.donech
+}
+
+func (m *monitor) run() {
+	defer close(m.donech)
+	defer m.log.Debug("done")
+
+	runner := NewRunner(m.ctx, m.cfg.Timeout, m.cfg.Fetch)
+	runnerch := runner.Done()
+
+	// set when refreshed while a fetch is running, which may
+	// have started before the change that prompted the refresh.
+	pending := false
+
+loop:
+	for {
+		select {
+
+		case <-m.ctx.Done():
+			break loop
+
+		case <-runnerch:
+
+			result, err := runner.Result(), runner.Err()
+
+			runner = nil
+			runnerch = nil
+
+			switch {
+			case err != nil && m.cfg.Gone != nil && m.cfg.Gone(err):
+				m.log.WithError(err).Debug("container gone")
+				break loop
+			case err != nil:
+				m.log.WithError(err).Warn("runner failed")
+			default:
+				m.log.Debug("runner complete")
+				if err := m.cfg.Submit(result); err != nil {
+					m.log.WithError(err).Warn("error submitting container")
+				}
+			}
+
+			if pending {
+				pending = false
+				runner = NewRunner(m.ctx, m.cfg.Timeout, m.cfg.Fetch)
+				runnerch = runner.Done()
+			}
+
+		case <-m.refreshch:
+
+			if runner != nil {
+				pending = true
+				continue
+			}
+
+			m.log.Debug("beginning refresh")
+
+			runner = NewRunner(m.ctx, m.cfg.Timeout, m.cfg.Fetch)
+			runnerch = runner.Done()
+
+		}
+	}
+
+	m.cancel()
+
+	if runner != nil {
+		<-runner.Done()
+	}
+}
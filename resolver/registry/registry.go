@@ -0,0 +1,581 @@
+// Package registry finds the container that a PID belongs to among
+// the containers reported by a container runtime.  It is shared by the
+// docker, podman and containerd resolvers, which wrap it with their
+// own container types, along with the Watcher and Monitor loops that
+// keep it up to date from the runtime's events.
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/boz/circumspect/resolver/cgroup"
+	ps "github.com/mitchellh/go-ps"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrInvalidPid = errors.New("Invalid PID")
+	ErrNotFound   = errors.New("Not found")
+	ErrNotRunning = errors.New("no longer running")
+)
+
+// LookupTimeoutError is returned by Lookup when the cgroup of a PID names
+// a container that did not become known before the lookup timed out.
+type LookupTimeoutError struct {
+	ID string
+}
+
+func (e *LookupTimeoutError) Error() string {
+	return fmt.Sprintf("container %v not found before lookup timeout", e.ID)
+}
+
+// Timeout is true; the PID is in a container, but it couldn't be resolved.
+func (e *LookupTimeoutError) Timeout() bool {
+	return true
+}
+
+// Container is a running container as submitted to the Registry.
+type Container struct {
+	// Key identifies the container and must be comparable.  It is the
+	// ID unless IDs are only unique within some scope, as with
+	// containerd namespaces.
+	Key interface{}
+
+	// ID is the container's id as found in the cgroups of its processes.
+	ID string
+
+	// Pid is the PID of the container's root process, if it is running.
+	Pid int
+
+	// Value is the runtime's description of the container.  Watchers
+	// are signalled when it changes.
+	Value interface{}
+}
+
+// Config configures a Registry for a container runtime.
+type Config struct {
+	// Name of the runtime, used for logging: docker-id, podman-id, etc.
+	Runtime string
+
+	// cgroup runtimes (see cgroup.Container) whose containers may be
+	// managed by this runtime.  Cgroups of an unknown runtime are
+	// always searched.
+	CgroupRuntimes []string
+
+	LookupTimeout time.Duration
+
+	// Changed, if set, is called when a known container is submitted
+	// with a changed Value.  It is called from the registry's goroutine
+	// and must not block.
+	Changed func(prev, c Container)
+
+	Log logrus.FieldLogger
+}
+
+// Registry contains a set of running containers and
+// allows for finding which container a PID belongs to, if any.
+type Registry interface {
+
+	// Lookup will try to find the container that is running the given PID.
+	// If no container is found it will block until one becomes known.  If
+	// none does before the lookup timeout, a *LookupTimeoutError is returned
+	// if the PID's cgroup names a container, and ErrNotFound otherwise.
+	//
+	// The container is identified from the PID's cgroup.  If that isn't
+	// possible, the parent PIDs are searched for a container's root process.
+	Lookup(ctx context.Context, pid int) (Container, error)
+
+	// Submit notifies the registry of a new or updated container.
+	Submit(Container) error
+
+	// Remove removes the container with the given key.
+	Remove(key interface{}) error
+
+	// Sync replaces the set of running containers; containers that
+	// aren't given are removed.
+	Sync([]Container) error
+
+	// Watch returns a channel that is signalled each time a changed
+	// version of the container with the given key is submitted, and
+	// when it is removed.  The watch is removed when the given context
+	// is cancelled.
+	Watch(ctx context.Context, key interface{}) <-chan struct{}
+
+	Shutdown()
+	Done() <-chan struct{}
+}
+
+type registry struct {
+	lookupch chan *lookupRequest
+	submitch chan Container
+	removech chan interface{}
+	syncch   chan []Container
+	purgech  chan *waitingLookup
+
+	watchch   chan *watch
+	unwatchch chan *watch
+
+	waitingLookups []*waitingLookup
+	containers     map[interface{}]Container
+	watchers       map[interface{}][]*watch
+
+	// container keys by root process PID and by ID.
+	pids map[int]interface{}
+	ids  map[string]interface{}
+
+	cfg   Config
+	idKey string
+
+	donech chan struct{}
+	log    logrus.FieldLogger
+	cancel context.CancelFunc
+	ctx    context.Context
+}
+
+type lookupRequest struct {
+	pid int
+
+	// container id found from the cgroup of pid, if any.
+	id string
+
+	ch     chan<- Container
+	donech <-chan struct{}
+}
+
+func New(ctx context.Context, cfg Config) Registry {
+	ctx, cancel := context.WithCancel(ctx)
+
+	r := &registry{
+		lookupch: make(chan *lookupRequest),
+		submitch: make(chan Container),
+		removech: make(chan interface{}),
+		syncch:   make(chan []Container),
+		purgech:  make(chan *waitingLookup),
+
+		watchch:   make(chan *watch),
+		unwatchch: make(chan *watch),
+
+		containers: make(map[interface{}]Container),
+		watchers:   make(map[interface{}][]*watch),
+		pids:       make(map[int]interface{}),
+		ids:        make(map[string]interface{}),
+
+		cfg:   cfg,
+		idKey: cfg.Runtime + "-id",
+
+		donech: make(chan struct{}),
+		log:    cfg.Log,
+		cancel: cancel,
+		ctx:    ctx,
+	}
+
+	go r.run()
+
+	return r
+}
+
+func (r *registry) Shutdown() {
+	r.cancel()
+	<-r.donech
+}
+
+func (r *registry) Done() <-chan struct{} {
+	return r.donech
+}
+
+func (r *registry) Lookup(parent context.Context, pid int) (Container, error) {
+	ctx, cancel := context.WithTimeout(parent, r.cfg.LookupTimeout)
+	defer cancel()
+
+	id, err := r.containerIDForPid(pid)
+	if err != nil {
+		return Container{}, err
+	}
+
+	ch := make(chan Container, 1)
+
+	req := &lookupRequest{pid, id, ch, ctx.Done()}
+
+	// submit request
+	select {
+	case <-r.ctx.Done():
+		return Container{}, ErrNotRunning
+	case <-ctx.Done():
+		return Container{}, ctx.Err()
+	case r.lookupch <- req:
+	}
+
+	// wait for response or timeout
+	select {
+	case <-r.ctx.Done():
+		return Container{}, ErrNotRunning
+	case <-ctx.Done():
+		if err := parent.Err(); err != nil {
+			return Container{}, err
+		}
+		// the cgroup names a container that hasn't been seen yet;
+		// e.g. its event hasn't arrived or the first list isn't done.
+		if id != "" {
+			return Container{}, &LookupTimeoutError{id}
+		}
+		// no container was found for pid or any
+		// ancestor before the lookup timed out.
+		return Container{}, ErrNotFound
+	case c, ok := <-ch:
+
+		// ch is only closed if an invalid PID is given.
+		if !ok {
+			return Container{}, ErrInvalidPid
+		}
+		return c, nil
+	}
+}
+
+func (r *registry) Submit(c Container) error {
+	select {
+	case r.submitch <- c:
+		return nil
+	case <-r.ctx.Done():
+		return ErrNotRunning
+	}
+}
+
+func (r *registry) Remove(key interface{}) error {
+	select {
+	case r.removech <- key:
+		return nil
+	case <-r.ctx.Done():
+		return ErrNotRunning
+	}
+}
+
+func (r *registry) Sync(containers []Container) error {
+	select {
+	case r.syncch <- containers:
+		return nil
+	case <-r.ctx.Done():
+		return ErrNotRunning
+	}
+}
+
+func (r *registry) Watch(ctx context.Context, key interface{}) <-chan struct{} {
+	w := &watch{key, make(chan struct{}, 1)}
+
+	select {
+	case <-r.ctx.Done():
+		return nil
+	case <-ctx.Done():
+		return nil
+	case r.watchch <- w:
+	}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-r.ctx.Done():
+		}
+		r.unwatchch <- w
+	}()
+
+	return w.ch
+}
+
+func (r *registry) run() {
+	defer close(r.donech)
+	defer r.log.Debug("done")
+
+loop:
+	for {
+
+		select {
+
+		case <-r.ctx.Done():
+			break loop
+
+		case c := <-r.submitch:
+			r.doSubmit(c)
+
+		case key := <-r.removech:
+			r.doRemove(key)
+
+		case containers := <-r.syncch:
+			r.doSync(containers)
+
+		case req := <-r.lookupch:
+			r.doLookup(req)
+
+		case lookup := <-r.purgech:
+			r.purgeLookup(lookup)
+
+		case w := <-r.watchch:
+			r.watchers[w.key] = append(r.watchers[w.key], w)
+
+		case w := <-r.unwatchch:
+			r.removeWatch(w)
+
+		}
+	}
+
+	r.log.Debugf("draining %v lookups", len(r.waitingLookups))
+
+	// drain waiting lookups
+	for len(r.waitingLookups) > 0 {
+		r.purgeLookup(<-r.purgech)
+	}
+
+	r.log.Debugf("draining watches for %v containers", len(r.watchers))
+
+	for len(r.watchers) > 0 {
+		r.removeWatch(<-r.unwatchch)
+	}
+}
+
+func (r *registry) doSubmit(c Container) {
+	// see if there are any lookups waiting for this container.
+	for _, lookup := range r.waitingLookups {
+		if lookup.accept(c) {
+			r.resolve(lookup, c)
+		}
+	}
+
+	prev, found := r.containers[c.Key]
+
+	r.containers[c.Key] = c
+	r.ids[c.ID] = c.Key
+
+	if found && prev.Pid != c.Pid && r.pids[prev.Pid] == c.Key {
+		delete(r.pids, prev.Pid)
+	}
+	if c.Pid > 0 {
+		r.pids[c.Pid] = c.Key
+	}
+
+	// notify watchers of changes to a known container.
+	if found && !reflect.DeepEqual(prev.Value, c.Value) {
+		if r.cfg.Changed != nil {
+			r.cfg.Changed(prev, c)
+		}
+		r.signal(c.Key)
+	}
+}
+
+func (r *registry) doRemove(key interface{}) {
+	c, ok := r.containers[key]
+	if !ok {
+		return
+	}
+
+	r.log.WithField(r.idKey, c.ID).Debug("removing container")
+
+	delete(r.containers, key)
+
+	if r.ids[c.ID] == key {
+		delete(r.ids, c.ID)
+	}
+	if r.pids[c.Pid] == key {
+		delete(r.pids, c.Pid)
+	}
+
+	r.signal(key)
+}
+
+func (r *registry) doSync(containers []Container) {
+	current := make(map[interface{}]bool)
+	for _, c := range containers {
+		current[c.Key] = true
+	}
+
+	for key := range r.containers {
+		if !current[key] {
+			r.doRemove(key)
+		}
+	}
+
+	for _, c := range containers {
+		r.doSubmit(c)
+	}
+}
+
+func (r *registry) doLookup(req *lookupRequest) {
+	log := r.log.WithField("request-pid", req.pid)
+	log.Debug("looking up container")
+
+	if req.id != "" {
+		if key, ok := r.ids[req.id]; ok {
+			log.WithField(r.idKey, req.id).Debugf("cgroup match found")
+			req.ch <- r.containers[key]
+			return
+		}
+
+		log.WithField(r.idKey, req.id).Debug("no match found.  waiting for container")
+		r.waitForContainer(&waitingLookup{req, nil, log.WithField("waiting", true)})
+		return
+	}
+
+	var pids []int
+
+	pid := req.pid
+
+	// starting with the given pid, check if there are any containers
+	// whose root process has the same pid.
+	// repeat with the parent pid until a container is found
+	// or the pid is init (pid == 1).
+	for pid > 1 {
+
+		if key, ok := r.pids[pid]; ok {
+			c := r.containers[key]
+
+			log.WithField("pid", pid).
+				WithField(r.idKey, c.ID).
+				Debugf("match found")
+
+			req.ch <- c
+			return
+		}
+
+		pids = append(pids, pid)
+
+		p, err := ps.FindProcess(pid)
+		if err != nil || p == nil {
+			break
+		}
+
+		pid = p.PPid()
+
+	}
+
+	// if no valid pids were found,
+	// this was somehow a bogus PID.
+	if len(pids) == 0 {
+		close(req.ch)
+		return
+	}
+
+	log.Debug("no match found.  waiting for new containers")
+
+	// no containers were found.
+	// save all pid generations and wait for a new
+	// container to be submitted that matches
+
+	r.waitForContainer(&waitingLookup{req, pids, log.WithField("waiting", true)})
+}
+
+// waitForContainer saves the lookup until a matching container
+// is submitted or the request is done.
+func (r *registry) waitForContainer(lookup *waitingLookup) {
+	r.waitingLookups = append(r.waitingLookups, lookup)
+
+	go func() {
+		<-lookup.request.donech
+		r.purgech <- lookup
+	}()
+}
+
+func (r *registry) resolve(lookup *waitingLookup, c Container) {
+	lookup.log.WithField("pid", c.Pid).
+		WithField(r.idKey, c.ID).
+		Debugf("match found")
+	select {
+	case lookup.request.ch <- c:
+	case <-lookup.request.donech:
+	}
+}
+
+func (r *registry) purgeLookup(lookup *waitingLookup) {
+	r.log.WithField("request-pid", lookup.request.pid).Debugf("purging lookup")
+
+	for idx, item := range r.waitingLookups {
+		if item == lookup {
+			r.waitingLookups = append(r.waitingLookups[:idx], r.waitingLookups[idx+1:]...)
+			return
+		}
+	}
+}
+
+func (r *registry) signal(key interface{}) {
+	for _, w := range r.watchers[key] {
+		w.signal()
+	}
+}
+
+func (r *registry) removeWatch(w *watch) {
+	watchers := r.watchers[w.key]
+
+	for idx, item := range watchers {
+		if item == w {
+			watchers = append(watchers[:idx], watchers[idx+1:]...)
+			break
+		}
+	}
+
+	if len(watchers) == 0 {
+		delete(r.watchers, w.key)
+		return
+	}
+
+	r.watchers[w.key] = watchers
+}
+
+// containerIDForPid returns the id of the container that pid is
+// running in, as found in its cgroup.  An empty id is returned if
+// the cgroup couldn't be read or doesn't name a container.
+func (r *registry) containerIDForPid(pid int) (string, error) {
+	c, err := cgroup.ForPid(pid)
+	switch err {
+	case nil:
+	case cgroup.ErrAmbiguous, cgroup.ErrUnrecognized:
+		r.log.WithError(err).WithField("pid", pid).Warn("rejecting pid")
+		return "", ErrInvalidPid
+	default:
+		return "", nil
+	}
+
+	if c.Runtime == "" {
+		return c.ID, nil
+	}
+
+	for _, runtime := range r.cfg.CgroupRuntimes {
+		if c.Runtime == runtime {
+			return c.ID, nil
+		}
+	}
+
+	// managed by another runtime.
+	return "", ErrNotFound
+}
+
+type watch struct {
+	key interface{}
+	ch  chan struct{}
+}
+
+// signal notifies the watcher without blocking.  Pending
+// signals are coalesced.
+func (w *watch) signal() {
+	select {
+	case w.ch <- struct{}{}:
+	default:
+	}
+}
+
+type waitingLookup struct {
+	request *lookupRequest
+	pids    []int
+	log     logrus.FieldLogger
+}
+
+func (lookup *waitingLookup) accept(c Container) bool {
+	if lookup.request.id != "" {
+		return lookup.request.id == c.ID
+	}
+	for _, item := range lookup.pids {
+		if item == c.Pid {
+			return true
+		}
+	}
+	return false
+}
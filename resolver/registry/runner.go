@@ -1,7 +1,12 @@
-package docker
+package registry
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
+// Runner runs an Operation once in its own goroutine, cancelling it
+// after a timeout.
 type Runner interface {
 	Result() interface{}
 	Stop()
@@ -9,10 +14,11 @@ type Runner interface {
 	Err() error
 }
 
+// Operation is a request made to a container runtime.
 type Operation func(context.Context) (interface{}, error)
 
-func NewRunner(ctx context.Context, op Operation) Runner {
-	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+func NewRunner(ctx context.Context, timeout time.Duration, op Operation) Runner {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 
 	r := &runner{
 		op:     op,
@@ -56,7 +62,5 @@ func (r *runner) run() {
 	defer close(r.donech)
 	defer r.cancel()
 
-	// todo: retry
-
 	r.result, r.err = r.op(r.ctx)
 }
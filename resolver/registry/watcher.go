@@ -0,0 +1,127 @@
+package registry
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+const watcherBufsiz = 20
+
+const (
+	EventTypeCreate EventType = "create"
+	EventTypeUpdate EventType = "update"
+	EventTypeDelete EventType = "delete"
+)
+
+type EventType string
+
+// WatchEvent is a change to the container with the given key; see
+// Container.Key.
+type WatchEvent struct {
+	Type EventType
+	Key  interface{}
+}
+
+// EventStream is a stream of events from a container runtime.
+type EventStream interface {
+	// Next blocks until the next event is received.  ok is false if
+	// the event is not one that is watched.
+	Next() (event WatchEvent, ok bool, err error)
+
+	Close() error
+}
+
+// Subscribe opens an EventStream, which must end when ctx is cancelled.
+type Subscribe func(ctx context.Context) (EventStream, error)
+
+// Watcher watches for container events from a runtime and exposes
+// them via the Events() method.  Events are dropped if they are not
+// read in time.  The Watcher stops at the first error.
+type Watcher interface {
+	Events() <-chan WatchEvent
+	Shutdown()
+	Err() error
+	Done() <-chan struct{}
+}
+
+func NewWatcher(ctx context.Context, subscribe Subscribe, log logrus.FieldLogger) Watcher {
+	ctx, cancel := context.WithCancel(ctx)
+
+	w := &watcher{
+		subscribe: subscribe,
+		eventch:   make(chan WatchEvent, watcherBufsiz),
+		donech:    make(chan struct{}),
+		log:       log.WithField("component", "watcher"),
+		cancel:    cancel,
+		ctx:       ctx,
+	}
+
+	go w.run()
+
+	return w
+}
+
+type watcher struct {
+	subscribe Subscribe
+	eventch   chan WatchEvent
+	donech    chan struct{}
+	err       error
+	log       logrus.FieldLogger
+	cancel    context.CancelFunc
+	ctx       context.Context
+}
+
+func (w *watcher) Events() <-chan WatchEvent {
+	return w.eventch
+}
+
+func (w *watcher) Shutdown() {
+	w.cancel()
+	<-w.donech
+}
+
+func (w *watcher) Done() <-chan struct{} {
+	return w.donech
+}
+
+func (w *watcher) Err() error {
+	<-w.donech
+	return w.err
+}
+
+func (w *watcher) run() {
+	defer close(w.donech)
+	defer w.log.Debug("done")
+	defer w.cancel()
+
+	stream, err := w.subscribe(w.ctx)
+	if err != nil {
+		w.log.WithError(err).Error("error subscribing to events")
+		w.err = err
+		return
+	}
+	defer stream.Close()
+
+	for w.ctx.Err() == nil {
+
+		event, ok, err := stream.Next()
+		if err != nil {
+			if w.ctx.Err() == nil {
+				w.log.WithError(err).Error("error receiving event")
+				w.err = err
+			}
+			return
+		}
+
+		if !ok {
+			continue
+		}
+
+		select {
+		case w.eventch <- event:
+		default:
+			w.log.Warn("dropping event")
+		}
+	}
+}
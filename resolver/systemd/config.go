@@ -0,0 +1,50 @@
+package systemd
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	defaultTimeout  = 2 * time.Second
+	defaultCacheTTL = time.Minute
+)
+
+// Config configures the systemd resolver.
+type Config struct {
+	// Fetch the description, fragment path, User= and DynamicUser= of
+	// system units from the systemd manager over D-Bus.
+	DBus bool `yaml:"dbus"`
+
+	// D-Bus address of the system bus.  Defaults to
+	// $DBUS_SYSTEM_BUS_ADDRESS, or /var/run/dbus/system_bus_socket.
+	Address string `yaml:"address,omitempty"`
+
+	// Timeout for D-Bus requests.
+	Timeout time.Duration `yaml:"timeout"`
+
+	// How long the properties of a unit are cached for.
+	CacheTTL time.Duration `yaml:"cache-ttl"`
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Timeout:  defaultTimeout,
+		CacheTTL: defaultCacheTTL,
+	}
+}
+
+func (c Config) Validate() error {
+	if c.Timeout <= 0 {
+		return fmt.Errorf("timeout: must be positive (got %v)", c.Timeout)
+	}
+	if c.CacheTTL <= 0 {
+		return fmt.Errorf("cache-ttl: must be positive (got %v)", c.CacheTTL)
+	}
+	if c.Address != "" {
+		if _, err := dbusSocketPath(c.Address); err != nil {
+			return fmt.Errorf("address: %v", err)
+		}
+	}
+	return nil
+}
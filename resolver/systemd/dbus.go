@@ -0,0 +1,424 @@
+package systemd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// A minimal D-Bus client: enough to call methods with string arguments
+// and read string, object path and boolean results.  See
+// https://dbus.freedesktop.org/doc/dbus-specification.html
+
+const (
+	dbusSystemBusAddress = "unix:path=/var/run/dbus/system_bus_socket"
+
+	// message types
+	dbusMethodCall   = 1
+	dbusMethodReturn = 2
+	dbusMethodError  = 3
+
+	// header fields
+	dbusFieldPath        = 1
+	dbusFieldInterface   = 2
+	dbusFieldMember      = 3
+	dbusFieldErrorName   = 4
+	dbusFieldReplySerial = 5
+	dbusFieldDestination = 6
+	dbusFieldSignature   = 8
+
+	// maximum message size
+	dbusMaxMessage = 1 << 27
+)
+
+var errDBusMalformed = errors.New("dbus: malformed message")
+
+// dbusError is an error returned by the remote method.
+type dbusError struct {
+	Name    string
+	Message string
+}
+
+func (e *dbusError) Error() string {
+	if e.Message == "" {
+		return e.Name
+	}
+	return fmt.Sprintf("%v: %v", e.Name, e.Message)
+}
+
+type dbusConn struct {
+	conn   net.Conn
+	rd     *bufio.Reader
+	serial uint32
+}
+
+// dialDBus connects and authenticates to the bus at address, which
+// defaults to the system bus.  The deadline of ctx applies to all calls
+// made on the connection.
+func dialDBus(ctx context.Context, address string) (*dbusConn, error) {
+	path, err := dbusSocketPath(address)
+	if err != nil {
+		return nil, err
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "unix", path)
+	if err != nil {
+		return nil, err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	c := &dbusConn{conn: conn, rd: bufio.NewReader(conn)}
+
+	if err := c.auth(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if _, err := c.call("org.freedesktop.DBus", "/org/freedesktop/DBus", "org.freedesktop.DBus", "Hello"); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// dbusSocketPath returns the path of the first unix socket in a D-Bus
+// server address; abstract sockets are given as "@name".
+func dbusSocketPath(address string) (string, error) {
+	if address == "" {
+		address = os.Getenv("DBUS_SYSTEM_BUS_ADDRESS")
+	}
+	if address == "" {
+		address = dbusSystemBusAddress
+	}
+
+	for _, addr := range strings.Split(address, ";") {
+		if !strings.HasPrefix(addr, "unix:") {
+			continue
+		}
+		for _, kv := range strings.Split(strings.TrimPrefix(addr, "unix:"), ",") {
+			switch {
+			case strings.HasPrefix(kv, "path="):
+				return strings.TrimPrefix(kv, "path="), nil
+			case strings.HasPrefix(kv, "abstract="):
+				return "@" + strings.TrimPrefix(kv, "abstract="), nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("dbus: no unix socket in address %q", address)
+}
+
+func (c *dbusConn) Close() error {
+	return c.conn.Close()
+}
+
+// auth authenticates as the current user with the EXTERNAL mechanism.
+func (c *dbusConn) auth() error {
+	uid := strconv.Itoa(os.Getuid())
+
+	if _, err := fmt.Fprintf(c.conn, "\x00AUTH EXTERNAL %x\r\n", uid); err != nil {
+		return err
+	}
+
+	line, err := c.rd.ReadString('\n')
+	if err != nil {
+		return err
+	}
+
+	if !strings.HasPrefix(line, "OK ") {
+		return fmt.Errorf("dbus: authentication failed: %v", strings.TrimSpace(line))
+	}
+
+	_, err = io.WriteString(c.conn, "BEGIN\r\n")
+	return err
+}
+
+// call invokes a method with string arguments and returns its reply.
+func (c *dbusConn) call(dest, path, iface, member string, args ...string) (*dbusMessage, error) {
+	c.serial++
+	serial := c.serial
+
+	var body dbusEncoder
+	for _, arg := range args {
+		body.string(arg)
+	}
+
+	var msg dbusEncoder
+	msg.byte('l')
+	msg.byte(dbusMethodCall)
+	msg.byte(0)
+	msg.byte(1)
+	msg.uint32(uint32(body.buf.Len()))
+	msg.uint32(serial)
+
+	fields := msg.beginArray(8)
+	msg.field(dbusFieldPath, "o", path)
+	msg.field(dbusFieldInterface, "s", iface)
+	msg.field(dbusFieldMember, "s", member)
+	msg.field(dbusFieldDestination, "s", dest)
+	if len(args) > 0 {
+		msg.field(dbusFieldSignature, "g", strings.Repeat("s", len(args)))
+	}
+	msg.endArray(fields)
+
+	msg.align(8)
+	msg.buf.Write(body.buf.Bytes())
+
+	if _, err := c.conn.Write(msg.buf.Bytes()); err != nil {
+		return nil, err
+	}
+
+	for {
+		reply, err := c.readMessage()
+		if err != nil {
+			return nil, err
+		}
+
+		// skip signals and replies to other calls.
+		if reply.replySerial != serial {
+			continue
+		}
+
+		switch reply.typ {
+		case dbusMethodReturn:
+			return reply, nil
+		case dbusMethodError:
+			derr := &dbusError{Name: reply.errorName}
+			if strings.HasPrefix(reply.signature, "s") {
+				derr.Message = reply.body.string()
+			}
+			return nil, derr
+		}
+	}
+}
+
+type dbusMessage struct {
+	typ         byte
+	replySerial uint32
+	errorName   string
+	signature   string
+	body        *dbusDecoder
+}
+
+func (c *dbusConn) readMessage() (*dbusMessage, error) {
+	hdr := make([]byte, 16)
+	if _, err := io.ReadFull(c.rd, hdr); err != nil {
+		return nil, err
+	}
+
+	var order binary.ByteOrder
+	switch hdr[0] {
+	case 'l':
+		order = binary.LittleEndian
+	case 'B':
+		order = binary.BigEndian
+	default:
+		return nil, errDBusMalformed
+	}
+
+	bodyLen := int(order.Uint32(hdr[4:]))
+	fieldsLen := int(order.Uint32(hdr[12:]))
+
+	if bodyLen > dbusMaxMessage || fieldsLen > dbusMaxMessage {
+		return nil, errDBusMalformed
+	}
+
+	// header fields are padded to 8 bytes.
+	hdrLen := 16 + fieldsLen
+	bodyStart := (hdrLen + 7) &^ 7
+
+	buf := make([]byte, bodyStart+bodyLen)
+	copy(buf, hdr)
+	if _, err := io.ReadFull(c.rd, buf[16:]); err != nil {
+		return nil, err
+	}
+
+	msg := &dbusMessage{
+		typ:  hdr[1],
+		body: &dbusDecoder{order: order, buf: buf[bodyStart:]},
+	}
+
+	d := &dbusDecoder{order: order, buf: buf[:hdrLen], off: 16}
+
+	for d.err == nil && d.off < hdrLen {
+		d.align(8)
+		code := d.byte()
+
+		var value interface{}
+		switch sig := d.signature(); sig {
+		case "s", "o":
+			value = d.string()
+		case "g":
+			value = d.signature()
+		case "u":
+			value = d.uint32()
+		default:
+			return nil, fmt.Errorf("dbus: unsupported header field type %q", sig)
+		}
+
+		switch code {
+		case dbusFieldReplySerial:
+			msg.replySerial, _ = value.(uint32)
+		case dbusFieldErrorName:
+			msg.errorName, _ = value.(string)
+		case dbusFieldSignature:
+			msg.signature, _ = value.(string)
+		}
+	}
+
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	return msg, nil
+}
+
+// dbusEncoder writes little-endian values.  Values are aligned
+// relative to the start of buf.
+type dbusEncoder struct {
+	buf bytes.Buffer
+}
+
+func (e *dbusEncoder) align(n int) {
+	for e.buf.Len()%n != 0 {
+		e.buf.WriteByte(0)
+	}
+}
+
+func (e *dbusEncoder) byte(b byte) {
+	e.buf.WriteByte(b)
+}
+
+func (e *dbusEncoder) uint32(v uint32) {
+	e.align(4)
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], v)
+	e.buf.Write(b[:])
+}
+
+func (e *dbusEncoder) string(s string) {
+	e.uint32(uint32(len(s)))
+	e.buf.WriteString(s)
+	e.buf.WriteByte(0)
+}
+
+func (e *dbusEncoder) signature(s string) {
+	e.buf.WriteByte(byte(len(s)))
+	e.buf.WriteString(s)
+	e.buf.WriteByte(0)
+}
+
+// beginArray writes a placeholder length and returns the offset of
+// the array's first element, which is aligned to elemAlign.
+func (e *dbusEncoder) beginArray(elemAlign int) int {
+	e.uint32(0)
+	e.align(elemAlign)
+	return e.buf.Len()
+}
+
+// endArray sets the length of the array started at start.
+func (e *dbusEncoder) endArray(start int) {
+	binary.LittleEndian.PutUint32(e.buf.Bytes()[start-4:], uint32(e.buf.Len()-start))
+}
+
+// field writes a header field: a struct of its code and a variant.
+func (e *dbusEncoder) field(code byte, sig string, value string) {
+	e.align(8)
+	e.byte(code)
+	e.signature(sig)
+	if sig == "g" {
+		e.signature(value)
+	} else {
+		e.string(value)
+	}
+}
+
+// dbusDecoder reads values from buf.  The first error is kept in err;
+// reads after it return zero values.
+type dbusDecoder struct {
+	order binary.ByteOrder
+	buf   []byte
+	off   int
+	err   error
+}
+
+func (d *dbusDecoder) next(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if n < 0 || d.off+n > len(d.buf) {
+		d.err = errDBusMalformed
+		return nil
+	}
+	b := d.buf[d.off : d.off+n]
+	d.off += n
+	return b
+}
+
+func (d *dbusDecoder) align(n int) {
+	if pad := (n - d.off%n) % n; pad > 0 {
+		d.next(pad)
+	}
+}
+
+func (d *dbusDecoder) byte() byte {
+	if b := d.next(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (d *dbusDecoder) uint32() uint32 {
+	d.align(4)
+	if b := d.next(4); b != nil {
+		return d.order.Uint32(b)
+	}
+	return 0
+}
+
+func (d *dbusDecoder) string() string {
+	n := int(d.uint32())
+	if b := d.next(n + 1); b != nil {
+		return string(b[:n])
+	}
+	return ""
+}
+
+func (d *dbusDecoder) signature() string {
+	n := int(d.byte())
+	if b := d.next(n + 1); b != nil {
+		return string(b[:n])
+	}
+	return ""
+}
+
+// variant reads a variant holding a string, object path or boolean.
+func (d *dbusDecoder) variant() (interface{}, error) {
+	var value interface{}
+
+	switch sig := d.signature(); sig {
+	case "s", "o":
+		value = d.string()
+	case "b":
+		value = d.uint32() != 0
+	default:
+		if d.err == nil {
+			return nil, fmt.Errorf("dbus: unsupported variant type %q", sig)
+		}
+	}
+
+	return value, d.err
+}
@@ -0,0 +1,161 @@
+package systemd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	systemdService   = "org.freedesktop.systemd1"
+	systemdPath      = "/org/freedesktop/systemd1"
+	systemdManager   = "org.freedesktop.systemd1.Manager"
+	systemdUnit      = "org.freedesktop.systemd1.Unit"
+	systemdServiceIf = "org.freedesktop.systemd1.Service"
+	dbusProperties   = "org.freedesktop.DBus.Properties"
+)
+
+// Unit properties fetched from the system manager, by interface.
+var managerProperties = []struct {
+	iface string
+	name  string
+}{
+	{systemdUnit, "Description"},
+	{systemdUnit, "FragmentPath"},
+	{systemdServiceIf, "User"},
+	{systemdServiceIf, "DynamicUser"},
+}
+
+// manager fetches unit properties from the systemd manager
+// (org.freedesktop.systemd1) over D-Bus.  Properties are cached by
+// unit name.
+type manager struct {
+	address  string
+	cacheTTL time.Duration
+
+	cache map[string]unitCacheEntry
+	mtx   sync.Mutex
+}
+
+type unitCacheEntry struct {
+	props     map[string]string
+	fetchedAt time.Time
+}
+
+// newManager returns a manager for the bus at address, after checking
+// that it can be connected to.
+func newManager(address string, timeout, cacheTTL time.Duration) (*manager, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	conn, err := dialDBus(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	conn.Close()
+
+	return &manager{
+		address:  address,
+		cacheTTL: cacheTTL,
+		cache:    make(map[string]unitCacheEntry),
+	}, nil
+}
+
+// UnitProperties returns the non-empty managerProperties of the given
+// unit.  Booleans are given as yes or no, as systemctl shows them.
+func (m *manager) UnitProperties(ctx context.Context, unit string) (map[string]string, error) {
+	if props, ok := m.cached(unit); ok {
+		return props, nil
+	}
+
+	props, err := m.fetchUnitProperties(ctx, unit)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, err
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.cache[unit] = unitCacheEntry{props, time.Now()}
+
+	return props, nil
+}
+
+func (m *manager) cached(unit string) (map[string]string, bool) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	entry, ok := m.cache[unit]
+	if !ok {
+		return nil, false
+	}
+
+	if time.Since(entry.fetchedAt) > m.cacheTTL {
+		delete(m.cache, unit)
+		return nil, false
+	}
+
+	return entry.props, true
+}
+
+func (m *manager) fetchUnitProperties(ctx context.Context, unit string) (map[string]string, error) {
+	conn, err := dialDBus(ctx, m.address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	reply, err := conn.call(systemdService, systemdPath, systemdManager, "GetUnit", unit)
+	if err != nil {
+		return nil, err
+	}
+	if reply.signature != "o" {
+		return nil, fmt.Errorf("GetUnit: unexpected reply type %q", reply.signature)
+	}
+
+	path := reply.body.string()
+	if reply.body.err != nil {
+		return nil, reply.body.err
+	}
+
+	props := make(map[string]string)
+
+	for _, prop := range managerProperties {
+		// only services have User= and DynamicUser=.
+		if prop.iface == systemdServiceIf && !strings.HasSuffix(unit, ".service") {
+			continue
+		}
+
+		reply, err := conn.call(systemdService, path, dbusProperties, "Get", prop.iface, prop.name)
+		if err != nil {
+			return nil, err
+		}
+		if reply.signature != "v" {
+			return nil, fmt.Errorf("Get %v: unexpected reply type %q", prop.name, reply.signature)
+		}
+
+		value, err := reply.body.variant()
+		if err != nil {
+			return nil, err
+		}
+
+		switch value := value.(type) {
+		case string:
+			if value != "" {
+				props[prop.name] = value
+			}
+		case bool:
+			props[prop.name] = "no"
+			if value {
+				props[prop.name] = "yes"
+			}
+		}
+	}
+
+	return props, nil
+}
@@ -0,0 +1,87 @@
+package systemd
+
+import "github.com/boz/circumspect/propset"
+
+type Props interface {
+	SystemdUnit() string
+	SystemdSlice() string
+	SystemdUserUnit() string
+	SystemdSession() string
+	SystemdSeat() string
+
+	// Unit properties from the systemd manager, keyed by D-Bus
+	// property name (Description, FragmentPath, User, DynamicUser).
+	// Empty unless D-Bus is enabled.
+	SystemdUnitProperties() map[string]string
+
+	PropSet() propset.PropSet
+}
+
+type props struct {
+	unit      Unit
+	seat      string
+	unitprops map[string]string
+}
+
+func (p props) SystemdUnit() string {
+	return p.unit.Unit
+}
+
+func (p props) SystemdSlice() string {
+	return p.unit.Slice
+}
+
+func (p props) SystemdUserUnit() string {
+	return p.unit.UserUnit
+}
+
+func (p props) SystemdSession() string {
+	return p.unit.Session
+}
+
+func (p props) SystemdSeat() string {
+	return p.seat
+}
+
+func (p props) SystemdUnitProperties() map[string]string {
+	return p.unitprops
+}
+
+// property names of SystemdUnitProperties
+var unitPropertyNames = map[string]string{
+	"Description":  "systemd-description",
+	"FragmentPath": "systemd-fragment-path",
+	"User":         "systemd-user",
+	"DynamicUser":  "systemd-dynamic-user",
+}
+
+func (p props) PropSet() propset.PropSet {
+	pset := propset.New().
+		AddString("systemd-unit", p.SystemdUnit())
+
+	optional := map[string]string{
+		"systemd-slice":     p.SystemdSlice(),
+		"systemd-user-unit": p.SystemdUserUnit(),
+		"systemd-session":   p.SystemdSession(),
+		"systemd-seat":      p.SystemdSeat(),
+	}
+
+	for key, value := range p.SystemdUnitProperties() {
+		if name, ok := unitPropertyNames[key]; ok && key != "DynamicUser" {
+			optional[name] = value
+		}
+	}
+
+	for name, value := range optional {
+		if value != "" {
+			pset.AddString(name, value)
+		}
+	}
+
+	// booleans are given as yes or no; see manager.UnitProperties.
+	if value, ok := p.SystemdUnitProperties()["DynamicUser"]; ok && value != "" {
+		pset.AddBool(unitPropertyNames["DynamicUser"], value == "yes")
+	}
+
+	return pset
+}
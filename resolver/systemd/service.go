@@ -0,0 +1,136 @@
+package systemd
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrInvalidPid = errors.New("invalid PID")
+
+	pkglog = logrus.StandardLogger().WithField("package", "resolver/systemd")
+)
+
+type RequiredProps interface {
+	Pid() int
+}
+
+// Service resolves the systemd unit, slice and login session of a process
+// from its cgroup.  Unit properties are optionally fetched from the
+// systemd manager; if that fails, the unit is still returned without them.
+type Service interface {
+	Lookup(context.Context, RequiredProps) (Props, error)
+
+	// Ready is closed immediately; there is no state to load.
+	Ready() <-chan struct{}
+
+	Shutdown()
+	Done() <-chan struct{}
+}
+
+func NewService(ctx context.Context, cfg Config) (Service, error) {
+	log := pkglog.WithField("component", "service")
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	var mgr *manager
+
+	if cfg.DBus {
+		var err error
+		if mgr, err = newManager(cfg.Address, cfg.Timeout, cfg.CacheTTL); err != nil {
+			log.WithError(err).Error("can't query systemd manager")
+			return nil, err
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	s := &service{
+		manager: mgr,
+		timeout: cfg.Timeout,
+		readych: make(chan struct{}),
+		donech:  make(chan struct{}),
+		log:     log,
+		cancel:  cancel,
+		ctx:     ctx,
+	}
+
+	close(s.readych)
+
+	go s.run()
+
+	return s, nil
+}
+
+type service struct {
+	manager *manager
+	timeout time.Duration
+	readych chan struct{}
+	donech  chan struct{}
+	log     logrus.FieldLogger
+	cancel  context.CancelFunc
+	ctx     context.Context
+}
+
+func (s *service) Lookup(ctx context.Context, pprops RequiredProps) (Props, error) {
+	log := s.log.WithField("pid", pprops.Pid())
+
+	unit, err := ForPid(pprops.Pid())
+	switch {
+	case err == ErrNotFound:
+		return nil, ErrNotFound
+	case err != nil:
+		log.WithError(err).Debug("reading cgroup")
+		return nil, ErrInvalidPid
+	}
+
+	p := props{unit: unit}
+
+	if unit.Session != "" {
+		seat, err := sessionSeat(unit.Session)
+		if err != nil {
+			log.WithError(err).Debugf("reading session %v", unit.Session)
+		}
+		p.seat = seat
+	}
+
+	if s.manager != nil {
+		ctx, cancel := context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+
+		// the unit is known from the cgroup without them.
+		p.unitprops, err = s.manager.UnitProperties(ctx, unit.Unit)
+		if err != nil {
+			log.WithError(err).Warnf("fetching properties of %v", unit.Unit)
+		}
+	}
+
+	log.WithField("systemd-unit", unit.Unit).Debug("unit found")
+
+	return p, nil
+}
+
+func (s *service) Ready() <-chan struct{} {
+	return s.readych
+}
+
+func (s *service) Shutdown() {
+	s.cancel()
+	<-s.donech
+}
+
+func (s *service) Done() <-chan struct{} {
+	return s.donech
+}
+
+func (s *service) run() {
+	defer close(s.donech)
+	defer s.log.Debug("done")
+
+	<-s.ctx.Done()
+}
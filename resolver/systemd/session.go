@@ -0,0 +1,31 @@
+package systemd
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// logind's record of active sessions.
+const sessionsDir = "/run/systemd/sessions"
+
+// sessionSeat returns the seat of the given login session, or the
+// empty string if it has none (e.g. ssh sessions).
+func sessionSeat(session string) (string, error) {
+	file, err := os.Open(filepath.Join(sessionsDir, session))
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		if value := strings.TrimPrefix(scanner.Text(), "SEAT="); value != scanner.Text() {
+			return value, nil
+		}
+	}
+
+	return "", scanner.Err()
+}
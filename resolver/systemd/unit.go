@@ -0,0 +1,125 @@
+package systemd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var ErrNotFound = errors.New("no systemd unit found")
+
+// Unit identifies the systemd unit that a process belongs to.
+type Unit struct {
+	// Unit of the system manager; e.g. nginx.service or session-3.scope.
+	Unit string
+
+	// Innermost slice containing Unit; e.g. system.slice or user-1000.slice.
+	Slice string
+
+	// Unit of the user's service manager when Unit is user@<uid>.service;
+	// e.g. pipewire.service.
+	UserUnit string
+
+	// Login session id when Unit is a session scope.
+	Session string
+}
+
+var (
+	unitSuffixes = []string{".service", ".scope", ".socket", ".mount", ".swap"}
+
+	sessionExp = regexp.MustCompile(`^session-(.+)\.scope$`)
+)
+
+// ForPid returns the unit that the given process is running in.
+func ForPid(pid int) (Unit, error) {
+	file, err := os.Open(fmt.Sprintf("/proc/%v/cgroup", pid))
+	if err != nil {
+		return Unit{}, err
+	}
+	defer file.Close()
+
+	return Parse(file)
+}
+
+// Parse returns the unit found in the contents of a /proc/<pid>/cgroup file.
+// The systemd hierarchy is used: the unified (cgroup v2) hierarchy, or
+// name=systemd on cgroup v1.
+func Parse(r io.Reader) (Unit, error) {
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		// hierarchy-ID:controller-list:cgroup-path
+		parts := strings.SplitN(scanner.Text(), ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+
+		unified := parts[0] == "0" && parts[1] == ""
+		if !unified && parts[1] != "name=systemd" {
+			continue
+		}
+
+		if u, ok := ParsePath(parts[2]); ok {
+			return u, nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return Unit{}, err
+	}
+
+	return Unit{}, ErrNotFound
+}
+
+// ParsePath returns the unit found in a single cgroup path:
+//
+//	/system.slice/nginx.service
+//	/user.slice/user-1000.slice/session-3.scope
+//	/user.slice/user-1000.slice/user@1000.service/app.slice/pipewire.service
+func ParsePath(path string) (Unit, bool) {
+	var u Unit
+
+	for _, segment := range strings.Split(strings.Trim(path, "/"), "/") {
+		if strings.HasSuffix(segment, ".slice") {
+			if u.Unit == "" {
+				u.Slice = segment
+			}
+			continue
+		}
+
+		if !isUnit(segment) {
+			// a cgroup created inside a unit; e.g. by a container runtime.
+			break
+		}
+
+		if u.Unit != "" {
+			u.UserUnit = segment
+			break
+		}
+
+		u.Unit = segment
+
+		if m := sessionExp.FindStringSubmatch(segment); m != nil {
+			u.Session = m[1]
+		}
+
+		if !strings.HasPrefix(segment, "user@") {
+			break
+		}
+	}
+
+	return u, u.Unit != ""
+}
+
+func isUnit(name string) bool {
+	for _, suffix := range unitSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
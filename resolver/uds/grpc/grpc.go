@@ -1,39 +1,105 @@
 package grpc
 
 import (
+	"errors"
 	"net"
+	"sync"
+	"time"
 
 	"golang.org/x/net/context"
 
+	"github.com/boz/circumspect/propset"
 	"github.com/boz/circumspect/resolver/uds"
 
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 )
 
 const (
 	authType = "uds-grpc"
 )
 
-func NewCredentials() credentials.TransportCredentials {
-	return &txCredentials{}
+var (
+	ErrNoPeer        = errors.New("no peer information")
+	ErrNotUnixSocket = errors.New("peer not connected with unix socket credentials")
+)
+
+// Option configures the server credentials.
+type Option func(*txCredentials)
+
+// AllowAnonymous accepts peers whose credentials could not be read.
+// Handlers must check PropsFromContext for such peers.
+func AllowAnonymous() Option {
+	return func(c *txCredentials) {
+		c.allowAnonymous = true
+	}
 }
 
-type txCredentials struct{}
+// AllowLoopbackTCP identifies peers connected over loopback TCP by
+// looking up the process owning the client's socket.  See uds.FromTCPConn.
+func AllowLoopbackTCP() Option {
+	return func(c *txCredentials) {
+		c.allowTCP = true
+	}
+}
+
+// NewCredentials returns transport credentials that read the peer's
+// pid, uid, and gid from the unix socket.
+//
+// Peers whose credentials cannot be read are rejected during the handshake,
+// which clients see as codes.Unavailable, unless AllowAnonymous is given.  UnaryServerInterceptor and
+// StreamServerInterceptor reject requests that reach the server without
+// these credentials with codes.Unauthenticated.
+func NewCredentials(opts ...Option) credentials.TransportCredentials {
+	c := &txCredentials{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+type txCredentials struct {
+	allowAnonymous bool
+	allowTCP       bool
+}
 
 func (c *txCredentials) ClientHandshake(_ context.Context, _ string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
 	return conn, unknownAuthInfo{}, nil
 }
 
 func (c *txCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
-	props, err := uds.FromConn(conn)
+	props, err := c.fromConn(conn)
 
-	if err != nil {
-		// returning an error here hung the connection
-		return conn, unknownAuthInfo{err}, nil
+	switch {
+	case err != nil && c.allowAnonymous:
+		return conn, unknownAuthInfo{err: err, allowed: true}, nil
+	case err != nil:
+		conn.Close()
+		return nil, nil, err
 	}
 
-	return conn, authInfo{props}, nil
+	return &propsConn{conn, props}, &authInfo{props: props}, nil
+}
+
+func (c *txCredentials) fromConn(conn net.Conn) (uds.Props, error) {
+	if _, ok := conn.(*net.TCPConn); ok && c.allowTCP {
+		return uds.FromTCPConn(conn)
+	}
+	return uds.FromConn(conn)
+}
+
+// propsConn releases the peer's process handle when the connection is closed.
+type propsConn struct {
+	net.Conn
+	props uds.Props
+}
+
+func (c *propsConn) Close() error {
+	c.props.Close()
+	return c.Conn.Close()
 }
 
 func (c *txCredentials) Info() credentials.ProtocolInfo {
@@ -61,14 +127,135 @@ func PropsFromContext(ctx context.Context) (uds.Props, bool) {
 }
 
 func PropsFromAuthInfo(ai credentials.AuthInfo) (uds.Props, bool) {
-	if ai, ok := ai.(authInfo); ok {
+	if ai, ok := ai.(*authInfo); ok {
 		return ai.Props(), true
 	}
 	return nil, false
 }
 
+// ErrorFromAuthInfo returns the error that prevented the peer's
+// credentials from being read, or nil if they were read.
+func ErrorFromAuthInfo(ai credentials.AuthInfo) error {
+	switch ai := ai.(type) {
+	case *authInfo:
+		return nil
+	case unknownAuthInfo:
+		return ai.err
+	default:
+		return ErrNotUnixSocket
+	}
+}
+
+// Authenticate returns a codes.Unauthenticated status error if the peer
+// connected to ctx must be rejected.
+func Authenticate(ctx context.Context) error {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, ErrNoPeer.Error())
+	}
+
+	if ai, ok := p.AuthInfo.(unknownAuthInfo); ok && ai.allowed {
+		return nil
+	}
+
+	if err := ErrorFromAuthInfo(p.AuthInfo); err != nil {
+		return status.Errorf(codes.Unauthenticated, "reading peer credentials: %v", err)
+	}
+
+	return nil
+}
+
+// UnaryServerInterceptor rejects requests from unauthenticated peers.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := Authenticate(ctx); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor rejects streams from unauthenticated peers.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := Authenticate(ss.Context()); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// CachedPropSet returns the PropSet for the connection that ai belongs to,
+// resolving it with fn if it hasn't been or was resolved more than ttl ago.
+//
+// One lookup runs at a time per connection; concurrent callers wait for
+// its result until their own ctx is done.  The lookup isn't bound to any
+// caller's ctx, so a cancelled caller doesn't fail the others.  Errors are
+// not cached.
+func CachedPropSet(ctx context.Context, ai credentials.AuthInfo, ttl time.Duration,
+	fn func(context.Context, uds.Props) (propset.PropSet, error)) (propset.PropSet, bool, error) {
+
+	info, ok := ai.(*authInfo)
+	if !ok {
+		return nil, false, nil
+	}
+
+	info.mtx.Lock()
+	entry := info.entry
+	if entry == nil || entry.expired(ttl) {
+		entry = &cacheEntry{donech: make(chan struct{})}
+		info.entry = entry
+		go entry.resolve(info.props, fn)
+	}
+	info.mtx.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, true, ctx.Err()
+	case <-entry.donech:
+	}
+
+	if entry.err != nil {
+		info.mtx.Lock()
+		if info.entry == entry {
+			info.entry = nil
+		}
+		info.mtx.Unlock()
+		return nil, true, entry.err
+	}
+
+	return entry.pset, true, nil
+}
+
+// cacheEntry is a lookup of a connection's PropSet.  Its
+// fields are set before donech is closed.
+type cacheEntry struct {
+	donech     chan struct{}
+	pset       propset.PropSet
+	err        error
+	resolvedAt time.Time
+}
+
+func (e *cacheEntry) resolve(props uds.Props, fn func(context.Context, uds.Props) (propset.PropSet, error)) {
+	defer close(e.donech)
+	// resolvers time out their own lookups.
+	e.pset, e.err = fn(context.Background(), props)
+	e.resolvedAt = time.Now()
+}
+
+// expired returns true if the lookup completed with an error or more than ttl ago.
+func (e *cacheEntry) expired(ttl time.Duration) bool {
+	select {
+	case <-e.donech:
+		return e.err != nil || time.Since(e.resolvedAt) > ttl
+	default:
+		return false
+	}
+}
+
 type unknownAuthInfo struct {
-	err error
+	err     error
+	allowed bool
 }
 
 func (unknownAuthInfo) AuthType() string {
@@ -77,12 +264,16 @@ func (unknownAuthInfo) AuthType() string {
 
 type authInfo struct {
 	props uds.Props
+
+	// the latest lookup of the peer's properties; see CachedPropSet.
+	entry *cacheEntry
+	mtx   sync.Mutex
 }
 
-func (authInfo) AuthType() string {
+func (*authInfo) AuthType() string {
 	return authType
 }
 
-func (pi authInfo) Props() uds.Props {
+func (pi *authInfo) Props() uds.Props {
 	return pi.props
 }
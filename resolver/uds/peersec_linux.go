@@ -0,0 +1,27 @@
+// +build linux,!386
+
+package uds
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// maximum length of a security context returned by SO_PEERSEC.
+const peerSecMax = 4096
+
+// peerSecurityContext returns the LSM context of the peer of
+// the given socket, as captured when it connected.
+func peerSecurityContext(sock int) (string, error) {
+	buf := make([]byte, peerSecMax)
+	size := uint32(len(buf))
+
+	_, _, errno := syscall.Syscall6(syscall.SYS_GETSOCKOPT, uintptr(sock),
+		syscall.SOL_SOCKET, syscall.SO_PEERSEC,
+		uintptr(unsafe.Pointer(&buf[0])), uintptr(unsafe.Pointer(&size)), 0)
+	if errno != 0 {
+		return "", errno
+	}
+
+	return normalizeSecurityContext(buf[:size]), nil
+}
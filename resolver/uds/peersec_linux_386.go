@@ -0,0 +1,7 @@
+package uds
+
+// peerSecurityContext is not supported: getsockopt is multiplexed
+// through socketcall(2) on 386.
+func peerSecurityContext(sock int) (string, error) {
+	return "", ErrNotSupported
+}
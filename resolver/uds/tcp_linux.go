@@ -0,0 +1,369 @@
+// +build linux
+
+package uds
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+// FromTCPConn returns the properties of the process connected to the
+// server side of a loopback TCP connection.
+//
+// The peer's socket is found in the kernel's TCP table by its address
+// pair, and the process holding it by scanning /proc/*/fd.  The table
+// of the server's network namespace is searched first; if the
+// connection isn't there (e.g. the listener was created in a
+// container's namespace) the namespaces of other processes are searched
+// for the table containing the server's own socket.  Reading the
+// descriptors of other users' processes requires CAP_SYS_PTRACE.
+//
+// The connection is rejected if more than one process holds the socket,
+// or if the process's effective uid is not the uid that created it: the
+// socket may have been inherited by or passed to another process, and
+// which of them is the peer can't be known.
+func FromTCPConn(conn net.Conn) (Props, error) {
+	tconn, ok := conn.(*net.TCPConn)
+	if !ok {
+		return nil, ErrInvalidConnection
+	}
+
+	laddr, lok := tconn.LocalAddr().(*net.TCPAddr)
+	raddr, rok := tconn.RemoteAddr().(*net.TCPAddr)
+	if !lok || !rok {
+		return nil, ErrInvalidConnection
+	}
+
+	if !laddr.IP.IsLoopback() || !raddr.IP.IsLoopback() {
+		return nil, ErrNotLoopback
+	}
+
+	file, err := tconn.File()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var st syscall.Stat_t
+	if err := syscall.Fstat(int(file.Fd()), &st); err != nil {
+		return nil, err
+	}
+
+	peer, err := findTCPPeer(laddr, raddr, st.Ino)
+	if err != nil {
+		return nil, err
+	}
+
+	pid, err := socketOwner(peer.inode)
+	if err != nil {
+		return nil, err
+	}
+
+	pidfd := openPidfd(pid)
+
+	// the process may have exited, and its PID been reused, after the
+	// descriptors were scanned.
+	start, err := processStartTime(pid)
+	if err != nil || !ownsSocket(pid, peer.inode) {
+		if pidfd != nil {
+			pidfd.Close()
+		}
+		return nil, &ProcessChangedError{Pid: pid}
+	}
+
+	uid, err := processID(pid, "Uid:")
+	if err == nil && uid != peer.uid {
+		err = fmt.Errorf("tcp peer %v: pid %v has uid %v; socket created by uid %v", raddr, pid, uid, peer.uid)
+	}
+	if err != nil {
+		if pidfd != nil {
+			pidfd.Close()
+		}
+		return nil, err
+	}
+
+	gid, err := processID(pid, "Gid:")
+	if err != nil {
+		if pidfd != nil {
+			pidfd.Close()
+		}
+		return nil, err
+	}
+
+	seclabel, _ := processSecurityContext(pid)
+
+	return newProps(pid, peer.uid, gid, seclabel, pidfd, start), nil
+}
+
+// tcpEntry is a socket in /proc/net/tcp or /proc/net/tcp6.
+type tcpEntry struct {
+	local  *net.TCPAddr
+	remote *net.TCPAddr
+	uid    uint
+	inode  uint64
+}
+
+// findTCPPeer returns the entry of the peer of the server socket with the
+// given inode, from the table of the network namespace containing it.
+func findTCPPeer(laddr, raddr *net.TCPAddr, inode uint64) (tcpEntry, error) {
+	entries, err := readTCPTables("/proc/self/net")
+	if err != nil {
+		return tcpEntry{}, err
+	}
+
+	if peer, ok := matchTCPPeer(entries, laddr, raddr, inode); ok {
+		return peer, nil
+	}
+
+	self, _ := os.Readlink("/proc/self/ns/net")
+
+	seen := map[string]bool{self: true}
+
+	for _, pid := range listPids() {
+		ns, err := os.Readlink(fmt.Sprintf("/proc/%v/ns/net", pid))
+		if err != nil || seen[ns] {
+			continue
+		}
+		seen[ns] = true
+
+		entries, err := readTCPTables(fmt.Sprintf("/proc/%v/net", pid))
+		if err != nil {
+			continue
+		}
+
+		if peer, ok := matchTCPPeer(entries, laddr, raddr, inode); ok {
+			return peer, nil
+		}
+	}
+
+	return tcpEntry{}, fmt.Errorf("tcp peer %v: socket not found", raddr)
+}
+
+// matchTCPPeer returns the peer's entry if entries contains the server
+// socket with the given inode.  Requiring the server's own socket to be
+// present keeps a connection with the same addresses in another
+// namespace from being mistaken for the peer.
+func matchTCPPeer(entries []tcpEntry, laddr, raddr *net.TCPAddr, inode uint64) (tcpEntry, bool) {
+	var (
+		peer  tcpEntry
+		found bool
+		owner bool
+	)
+
+	for _, entry := range entries {
+		switch {
+		case entry.inode == 0:
+			// TIME_WAIT sockets aren't owned by a process.
+		case entry.inode == inode:
+			owner = true
+		case equalTCPAddr(entry.local, raddr) && equalTCPAddr(entry.remote, laddr):
+			peer, found = entry, true
+		}
+	}
+
+	return peer, found && owner
+}
+
+func equalTCPAddr(a, b *net.TCPAddr) bool {
+	return a.Port == b.Port && a.IP.Equal(b.IP)
+}
+
+// readTCPTables reads the IPv4 and IPv6 tables in the given
+// /proc/<pid>/net directory.
+func readTCPTables(dir string) ([]tcpEntry, error) {
+	var entries []tcpEntry
+
+	for _, name := range []string{"tcp", "tcp6"} {
+		found, err := readTCPTable(filepath.Join(dir, name))
+		switch {
+		case os.IsNotExist(err):
+			// IPv6 disabled.
+		case err != nil:
+			return nil, err
+		}
+		entries = append(entries, found...)
+	}
+
+	return entries, nil
+}
+
+// readTCPTable parses a table in the format of /proc/net/tcp:
+//
+//	sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+//	 0: 0100007F:1F90 0100007F:C350 01 00000000:00000000 00:00000000 00000000  1000        0 52310 ...
+func readTCPTable(path string) ([]tcpEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []tcpEntry
+
+	scanner := bufio.NewScanner(file)
+
+	// header
+	scanner.Scan()
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 10 {
+			continue
+		}
+
+		local, err := parseTCPAddr(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("%v: %v", path, err)
+		}
+
+		remote, err := parseTCPAddr(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("%v: %v", path, err)
+		}
+
+		uid, err := strconv.ParseUint(fields[7], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%v: uid: %v", path, err)
+		}
+
+		inode, err := strconv.ParseUint(fields[9], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%v: inode: %v", path, err)
+		}
+
+		entries = append(entries, tcpEntry{local, remote, uint(uid), inode})
+	}
+
+	return entries, scanner.Err()
+}
+
+// parseTCPAddr parses an address such as "0100007F:1F90".  The address
+// is printed as 32-bit words in host byte order; the port in hex.
+func parseTCPAddr(s string) (*net.TCPAddr, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("malformed address %q", s)
+	}
+
+	ip, err := hex.DecodeString(parts[0])
+	if err != nil || (len(ip) != net.IPv4len && len(ip) != net.IPv6len) {
+		return nil, fmt.Errorf("malformed address %q", s)
+	}
+
+	port, err := strconv.ParseUint(parts[1], 16, 16)
+	if err != nil {
+		return nil, fmt.Errorf("malformed port %q", s)
+	}
+
+	if littleEndian {
+		for i := 0; i < len(ip); i += 4 {
+			ip[i], ip[i+1], ip[i+2], ip[i+3] = ip[i+3], ip[i+2], ip[i+1], ip[i]
+		}
+	}
+
+	return &net.TCPAddr{IP: net.IP(ip), Port: int(port)}, nil
+}
+
+var littleEndian = func() bool {
+	x := uint16(1)
+	return *(*byte)(unsafe.Pointer(&x)) == 1
+}()
+
+// socketOwner returns the PID of the process holding a descriptor for
+// the socket with the given inode.  It is an error for more than one
+// process to hold one.
+func socketOwner(inode uint64) (int, error) {
+	var owners []int
+	for _, pid := range listPids() {
+		if ownsSocket(pid, inode) {
+			owners = append(owners, pid)
+		}
+	}
+
+	switch len(owners) {
+	case 0:
+		return 0, fmt.Errorf("socket %v: owner not found", inode)
+	case 1:
+		return owners[0], nil
+	default:
+		return 0, fmt.Errorf("socket %v: held by more than one process: %v", inode, owners)
+	}
+}
+
+// ownsSocket returns true if the process holds a descriptor
+// for the socket with the given inode.
+func ownsSocket(pid int, inode uint64) bool {
+	dir := fmt.Sprintf("/proc/%v/fd", pid)
+
+	names, err := readDirNames(dir)
+	if err != nil {
+		return false
+	}
+
+	target := fmt.Sprintf("socket:[%v]", inode)
+
+	for _, name := range names {
+		if link, err := os.Readlink(filepath.Join(dir, name)); err == nil && link == target {
+			return true
+		}
+	}
+
+	return false
+}
+
+// listPids returns the PIDs in /proc in ascending order.
+func listPids() []int {
+	names, _ := readDirNames("/proc")
+
+	var pids []int
+	for _, name := range names {
+		if pid, err := strconv.Atoi(name); err == nil {
+			pids = append(pids, pid)
+		}
+	}
+
+	sort.Ints(pids)
+	return pids
+}
+
+func readDirNames(dir string) ([]string, error) {
+	file, err := os.Open(dir)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return file.Readdirnames(-1)
+}
+
+// processID returns the effective id from the "Uid:" or "Gid:" line
+// of the status of a process.
+func processID(pid int, field string) (uint, error) {
+	buf, err := ioutil.ReadFile(fmt.Sprintf("/proc/%v/status", pid))
+	if err != nil {
+		return 0, err
+	}
+
+	for _, line := range strings.Split(string(buf), "\n") {
+		if !strings.HasPrefix(line, field) {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			break
+		}
+		id, err := strconv.ParseUint(fields[2], 10, 32)
+		return uint(id), err
+	}
+
+	return 0, fmt.Errorf("/proc/%v/status: no %v", pid, strings.TrimSuffix(field, ":"))
+}
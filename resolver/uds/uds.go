@@ -2,6 +2,9 @@ package uds
 
 import (
 	"errors"
+	"fmt"
+	"os"
+	"strings"
 
 	"github.com/boz/circumspect/propset"
 )
@@ -9,27 +12,71 @@ import (
 var (
 	ErrInvalidConnection = errors.New("invalid connection")
 	ErrNotSupported      = errors.New("unsupported host OS")
+	ErrNotLoopback       = errors.New("tcp peer not connected over loopback")
 )
 
+// ProcessChangedError is returned by Validate when the process has exited,
+// or its PID has been reused by another process, since it was identified.
+// Properties resolved by PID in the meantime may be those of another process.
+type ProcessChangedError struct {
+	Pid int
+
+	// Reused is true if the PID now belongs to a different process.
+	Reused bool
+}
+
+func (e *ProcessChangedError) Error() string {
+	if e.Reused {
+		return fmt.Sprintf("process %v replaced: pid reused", e.Pid)
+	}
+	return fmt.Sprintf("process %v exited", e.Pid)
+}
+
+// IsProcessChanged returns true if err is a *ProcessChangedError.
+func IsProcessChanged(err error) bool {
+	_, ok := err.(*ProcessChangedError)
+	return ok
+}
+
 type PidProps interface {
 	Pid() int
+
+	// SecurityContext returns the LSM context (SELinux label or AppArmor
+	// profile) of the process, or the empty string if there is none.
+	SecurityContext() string
+
 	PropSet() propset.PropSet
+
+	// Validate returns a *ProcessChangedError if the process identified
+	// by Pid() is no longer running.  It is called after properties have
+	// been resolved by PID to guard against PID reuse.
+	Validate() error
 }
 
 type Props interface {
 	PidProps
 	Uid() uint
 	Gid() uint
+
+	// Close releases the process handle used by Validate.
+	Close() error
 }
 
-func newProps(pid int, uid uint, gid uint) Props {
-	return &props{pid, uid, gid}
+func newProps(pid int, uid uint, gid uint, seclabel string, pidfd *os.File, start uint64) Props {
+	return &props{pid, uid, gid, seclabel, pidfd, start}
 }
 
 type props struct {
-	pid int
-	uid uint
-	gid uint
+	pid      int
+	uid      uint
+	gid      uint
+	seclabel string
+
+	// pidfd of the process, if supported.
+	pidfd *os.File
+
+	// start time of the process, in clock ticks after boot.
+	start uint64
 }
 
 func (p *props) Pid() int {
@@ -44,23 +91,76 @@ func (p *props) Gid() uint {
 	return p.gid
 }
 
+func (p *props) SecurityContext() string {
+	return p.seclabel
+}
+
 func (p *props) PropSet() propset.PropSet {
-	return propset.New().
+	pset := propset.New().
 		AddInt("system-pid", p.Pid()).
 		AddInt("system-uid", int(p.Uid())).
 		AddInt("system-gid", int(p.Gid()))
+	return addProcessProps(pset, p.seclabel, p.start)
 }
 
+func (p *props) Validate() error {
+	return validateProcess(p.pid, p.pidfd, p.start)
+}
+
+func (p *props) Close() error {
+	if p.pidfd == nil {
+		return nil
+	}
+	return p.pidfd.Close()
+}
+
+// NewPidProps returns the properties of the given process.  Its
+// start time is read so that Validate can detect PID reuse.
 func NewPidProps(pid int) PidProps {
-	return pidProps(pid)
+	start, _ := processStartTime(pid)
+	seclabel, _ := processSecurityContext(pid)
+	return &pidProps{pid, seclabel, start}
+}
+
+type pidProps struct {
+	pid      int
+	seclabel string
+	start    uint64
+}
+
+func (p *pidProps) Pid() int {
+	return p.pid
+}
+
+func (p *pidProps) SecurityContext() string {
+	return p.seclabel
 }
 
-type pidProps int
+func (p *pidProps) PropSet() propset.PropSet {
+	pset := propset.New().AddInt("system-pid", p.pid)
+	return addProcessProps(pset, p.seclabel, p.start)
+}
+
+func (p *pidProps) Validate() error {
+	return validateProcess(p.pid, nil, p.start)
+}
 
-func (p pidProps) Pid() int {
-	return int(p)
+// addProcessProps adds the security context and start time of the
+// process, where they are known.
+func addProcessProps(pset propset.PropSet, seclabel string, start uint64) propset.PropSet {
+	if seclabel != "" {
+		pset.AddString("system-security-context", seclabel)
+	}
+	if start != 0 {
+		if started, err := StartTime(start); err == nil {
+			pset.AddTime("system-start-time", started)
+		}
+	}
+	return pset
 }
 
-func (p pidProps) PropSet() propset.PropSet {
-	return propset.New().AddInt("system-pid", int(p))
+// normalizeSecurityContext strips the terminating NUL (SELinux) or
+// newline (AppArmor) from a security context.
+func normalizeSecurityContext(buf []byte) string {
+	return strings.TrimRight(string(buf), "\x00\n")
 }
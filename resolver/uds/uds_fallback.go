@@ -2,8 +2,32 @@
 
 package uds
 
-import "net"
+import (
+	"net"
+	"os"
+	"time"
+)
 
 func FromConn(conn net.Conn) (Props, error) {
 	return nil, ErrNotSupported
 }
+
+func FromTCPConn(conn net.Conn) (Props, error) {
+	return nil, ErrNotSupported
+}
+
+func processSecurityContext(pid int) (string, error) {
+	return "", ErrNotSupported
+}
+
+func processStartTime(pid int) (uint64, error) {
+	return 0, ErrNotSupported
+}
+
+func StartTime(ticks uint64) (time.Time, error) {
+	return time.Time{}, ErrNotSupported
+}
+
+func validateProcess(pid int, pidfd *os.File, start uint64) error {
+	return nil
+}
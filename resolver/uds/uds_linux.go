@@ -3,8 +3,30 @@
 package uds
 
 import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
 	"net"
+	"os"
+	"strconv"
+	"strings"
 	"syscall"
+	"time"
+)
+
+const (
+	// Linux USER_HZ; the unit of process start times.  It is 100
+	// on all supported architectures.
+	clockTicks = 100
+
+	// getsockopt(2) option returning a pidfd for the peer (linux 6.5).
+	soPeerPidfd = 77
+
+	// linux 5.3
+	sysPidfdOpen = 434
+
+	// linux 5.1
+	sysPidfdSendSignal = 424
 )
 
 func FromConn(conn net.Conn) (Props, error) {
@@ -25,5 +47,138 @@ func FromConn(conn net.Conn) (Props, error) {
 		return nil, err
 	}
 
-	return newProps(int(ucred.Pid), uint(ucred.Uid), uint(ucred.Gid)), nil
+	pid := int(ucred.Pid)
+
+	// empty if no LSM is enabled.
+	seclabel, _ := peerSecurityContext(int(file.Fd()))
+
+	pidfd := peerPidfd(int(file.Fd()), pid)
+
+	start, err := processStartTime(pid)
+	if err != nil {
+		if pidfd != nil {
+			pidfd.Close()
+		}
+		return nil, &ProcessChangedError{Pid: pid}
+	}
+
+	return newProps(pid, uint(ucred.Uid), uint(ucred.Gid), seclabel, pidfd, start), nil
+}
+
+// processSecurityContext returns the LSM context of a process.
+func processSecurityContext(pid int) (string, error) {
+	buf, err := ioutil.ReadFile(fmt.Sprintf("/proc/%v/attr/current", pid))
+	if err != nil {
+		return "", err
+	}
+	return normalizeSecurityContext(buf), nil
+}
+
+// peerPidfd returns a pidfd for the peer of the given socket, or nil if
+// pidfds aren't supported.  SO_PEERPIDFD refers to the process that
+// connected; a pidfd opened by PID refers to the process with that PID
+// now, which is why the start time is also checked by validateProcess.
+func peerPidfd(sock int, pid int) *os.File {
+	if fd, err := syscall.GetsockoptInt(sock, syscall.SOL_SOCKET, soPeerPidfd); err == nil {
+		return os.NewFile(uintptr(fd), fmt.Sprintf("pidfd:%v", pid))
+	}
+
+	return openPidfd(pid)
+}
+
+// openPidfd returns a pidfd for the process with the given PID,
+// or nil if pidfds aren't supported.
+func openPidfd(pid int) *os.File {
+	fd, _, errno := syscall.Syscall(sysPidfdOpen, uintptr(pid), 0, 0)
+	if errno != 0 {
+		return nil
+	}
+
+	return os.NewFile(fd, fmt.Sprintf("pidfd:%v", pid))
+}
+
+// processStartTime returns the start time of a process,
+// in clock ticks after boot.
+func processStartTime(pid int) (uint64, error) {
+	buf, err := ioutil.ReadFile(fmt.Sprintf("/proc/%v/stat", pid))
+	if err != nil {
+		return 0, err
+	}
+
+	// the command name (field 2) may contain spaces and parentheses.
+	stat := string(buf)
+	fields := strings.Fields(stat[strings.LastIndex(stat, ")")+1:])
+
+	// field 22; the 20th after the command name.
+	if len(fields) < 20 {
+		return 0, fmt.Errorf("/proc/%v/stat: malformed", pid)
+	}
+
+	return strconv.ParseUint(fields[19], 10, 64)
+}
+
+// StartTime returns the time at which a process started, given its
+// start time in clock ticks after boot as found in /proc/<pid>/stat.
+func StartTime(ticks uint64) (time.Time, error) {
+	boot, err := bootTime()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return boot.Add(time.Duration(ticks) * time.Second / clockTicks), nil
+}
+
+// bootTime returns the system boot time from /proc/stat.
+func bootTime() (time.Time, error) {
+	file, err := os.Open("/proc/stat")
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		if value := strings.TrimPrefix(scanner.Text(), "btime "); value != scanner.Text() {
+			secs, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return time.Time{}, err
+			}
+			return time.Unix(secs, 0), nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Time{}, fmt.Errorf("/proc/stat: no btime")
+}
+
+// validateProcess returns a *ProcessChangedError if the process has exited
+// or if the PID now belongs to a process with a different start time.
+func validateProcess(pid int, pidfd *os.File, start uint64) error {
+	if pidfd != nil {
+		// signal 0 checks that the process exists.
+		_, _, errno := syscall.Syscall6(sysPidfdSendSignal, pidfd.Fd(), 0, 0, 0, 0, 0)
+		if errno == syscall.ESRCH {
+			return &ProcessChangedError{Pid: pid}
+		}
+	}
+
+	// not known: NewPidProps was given a process that didn't exist.
+	if start == 0 {
+		return nil
+	}
+
+	current, err := processStartTime(pid)
+	switch {
+	case os.IsNotExist(err):
+		return &ProcessChangedError{Pid: pid}
+	case err != nil:
+		return err
+	case current != start:
+		return &ProcessChangedError{Pid: pid, Reused: true}
+	}
+
+	return nil
 }
@@ -1,39 +1,201 @@
 package rpc
 
 import (
+	"crypto/x509"
+	"io"
 	"net"
+	"strings"
 	"time"
 
 	context "golang.org/x/net/context"
 
+	"github.com/boz/circumspect/identity/jwt"
+	"github.com/boz/circumspect/identity/x509ca"
+	"github.com/boz/circumspect/propset"
 	grpc "google.golang.org/grpc"
 )
 
-func RunClient(ctx context.Context, path string) error {
+// RunClient registers with the server at the given path and returns
+// the properties that the server resolved for this process.
+func RunClient(ctx context.Context, path string) (propset.PropSet, error) {
 	log := pkglog.WithField("component", "client")
 
-	log.Debugf("connecting to %v ...", path)
+	conn, err := dial(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
 
-	dialer := func(addr string, timeout time.Duration) (net.Conn, error) {
-		d := net.Dialer{Timeout: timeout}
-		return d.DialContext(ctx, "unix", addr)
+	client := NewWorkloadClient(conn)
+
+	resp, err := client.Register(ctx, &Request{})
+	if err != nil {
+		log.WithError(err).Error("error registering")
+		return nil, err
 	}
 
-	conn, err := grpc.DialContext(ctx, path, grpc.WithInsecure(), grpc.WithDialer(dialer))
+	return resp.GetProps().PropSet(), nil
+}
 
+// FetchJWT requests a signed identity token for the given audience.
+func FetchJWT(ctx context.Context, path string, audience string, ttl time.Duration) (string, time.Time, error) {
+	log := pkglog.WithField("component", "client")
+
+	conn, err := dial(ctx, path)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	defer conn.Close()
+
+	client := NewWorkloadClient(conn)
+
+	resp, err := client.FetchJWT(ctx, &JWTRequest{
+		Audience: audience,
+		Ttl:      int64(ttl / time.Second),
+	})
+	if err != nil {
+		log.WithError(err).Error("error fetching jwt")
+		return "", time.Time{}, err
+	}
+
+	return resp.GetToken(), time.Unix(resp.GetExpiresAt(), 0), nil
+}
+
+// FetchJWKS retrieves the keys used to verify tokens issued by the server.
+func FetchJWKS(ctx context.Context, path string) (jwt.JWKS, error) {
+	log := pkglog.WithField("component", "client")
+
+	conn, err := dial(ctx, path)
+	if err != nil {
+		return jwt.JWKS{}, err
+	}
+	defer conn.Close()
+
+	client := NewWorkloadClient(conn)
+
+	resp, err := client.FetchJWKS(ctx, &JWKSRequest{})
+	if err != nil {
+		log.WithError(err).Error("error fetching jwks")
+		return jwt.JWKS{}, err
+	}
+
+	return jwt.ParseJWKS([]byte(resp.GetJwks()))
+}
+
+// FetchX509 requests a workload certificate.  If csr is empty
+// the server generates the private key.
+func FetchX509(ctx context.Context, path string, csr []byte, ttl time.Duration) (x509ca.Issued, error) {
+	log := pkglog.WithField("component", "client")
+
+	conn, err := dial(ctx, path)
+	if err != nil {
+		return x509ca.Issued{}, err
+	}
+	defer conn.Close()
+
+	client := NewWorkloadClient(conn)
+
+	resp, err := client.FetchX509(ctx, &X509Request{
+		Csr: csr,
+		Ttl: int64(ttl / time.Second),
+	})
+	if err != nil {
+		log.WithError(err).Error("error fetching certificate")
+		return x509ca.Issued{}, err
+	}
+
+	return x509ca.Issued{
+		Certificates: resp.GetCertificates(),
+		PrivateKey:   resp.GetPrivateKey(),
+		ExpiresAt:    time.Unix(resp.GetExpiresAt(), 0),
+	}, nil
+}
+
+// FetchX509Bundle retrieves the CA certificates that workload certificates chain to.
+func FetchX509Bundle(ctx context.Context, path string) ([]*x509.Certificate, error) {
+	log := pkglog.WithField("component", "client")
+
+	conn, err := dial(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	client := NewWorkloadClient(conn)
+
+	resp, err := client.FetchX509Bundle(ctx, &X509BundleRequest{})
+	if err != nil {
+		log.WithError(err).Error("error fetching bundle")
+		return nil, err
+	}
+
+	var certs []*x509.Certificate
+	for _, der := range resp.GetCertificates() {
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+
+	return certs, nil
+}
+
+// RunWatchClient streams identity updates from the server at the
+// given path, calling fn with each update until ctx is cancelled.
+func RunWatchClient(ctx context.Context, path string, req *WatchRequest, fn func(*WatchResponse)) error {
+	log := pkglog.WithField("component", "client")
+
+	conn, err := dial(ctx, path)
 	if err != nil {
-		log.WithError(err).Errorf("error connecting to %v", path)
 		return err
 	}
 	defer conn.Close()
 
 	client := NewWorkloadClient(conn)
 
-	_, err = client.Register(ctx, &Request{})
+	stream, err := client.Watch(ctx, req)
 	if err != nil {
-		log.WithError(err).Error("error registering")
+		log.WithError(err).Error("error watching")
 		return err
 	}
 
-	return nil
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			// the server ended the stream.
+			if ctx.Err() != nil || err == io.EOF {
+				return nil
+			}
+			log.WithError(err).Error("error receiving update")
+			return err
+		}
+		fn(resp)
+	}
+}
+
+func dial(ctx context.Context, path string) (*grpc.ClientConn, error) {
+	log := pkglog.WithField("component", "client")
+
+	log.Debugf("connecting to %v ...", path)
+
+	// legacy clients may connect over loopback tcp.
+	network := "unix"
+	if strings.HasPrefix(path, "tcp://") {
+		network, path = "tcp", strings.TrimPrefix(path, "tcp://")
+	}
+
+	dialer := func(addr string, timeout time.Duration) (net.Conn, error) {
+		d := net.Dialer{Timeout: timeout}
+		return d.DialContext(ctx, network, addr)
+	}
+
+	conn, err := grpc.DialContext(ctx, path, grpc.WithInsecure(), grpc.WithDialer(dialer))
+
+	if err != nil {
+		log.WithError(err).Errorf("error connecting to %v", path)
+		return nil, err
+	}
+
+	return conn, nil
 }
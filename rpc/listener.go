@@ -0,0 +1,288 @@
+package rpc
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/user"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/boz/circumspect/policy"
+)
+
+const (
+	// first file descriptor passed by systemd socket activation.
+	listenFdsStart = 3
+
+	tcpPrefix     = "tcp://"
+	systemdPrefix = "systemd"
+)
+
+// Listener is a socket that the server accepts clients on.
+//
+// Address is one of:
+//
+//	/run/circumspect.sock      a filesystem socket
+//	@circumspect               a linux abstract socket
+//	systemd                    all sockets passed by systemd (LISTEN_FDS)
+//	systemd:name               the sockets passed by systemd with FileDescriptorName=name
+//	tcp://127.0.0.1:7437       a loopback TCP socket (see udsgrpc.AllowLoopbackTCP)
+type Listener struct {
+	Address string
+
+	// Permissions and ownership of a filesystem socket.  Unchanged if zero or empty.
+	Mode  os.FileMode
+	Owner string
+	Group string
+
+	// Label is added to the properties of peers connected to this
+	// listener as server-listener, and to log entries.
+	Label string
+
+	// Policy, if given, must also allow peers connected to this listener.
+	Policy *policy.Policy
+}
+
+func (l Listener) String() string {
+	if l.Label != "" {
+		return fmt.Sprintf("%v (%v)", l.Address, l.Label)
+	}
+	return l.Address
+}
+
+// IsFilesystem returns true if the listener is a filesystem socket.
+func (l Listener) IsFilesystem() bool {
+	return !strings.HasPrefix(l.Address, "@") &&
+		!strings.HasPrefix(l.Address, tcpPrefix) &&
+		l.Address != systemdPrefix &&
+		!strings.HasPrefix(l.Address, systemdPrefix+":")
+}
+
+// Validate returns an error if the listener is malformed.
+func (l Listener) Validate() error {
+	switch {
+	case l.Address == "" || l.Address == "@":
+		return fmt.Errorf("address: required")
+	case l.IsFilesystem():
+	case l.Mode != 0:
+		return fmt.Errorf("mode: only applies to filesystem sockets (got %v)", l.Address)
+	case l.Owner != "":
+		return fmt.Errorf("owner: only applies to filesystem sockets (got %v)", l.Address)
+	case l.Group != "":
+		return fmt.Errorf("group: only applies to filesystem sockets (got %v)", l.Address)
+	}
+	return nil
+}
+
+// listen opens the sockets of l.  A systemd listener may have more than one.
+func listen(l Listener) ([]net.Listener, error) {
+	switch {
+	case strings.HasPrefix(l.Address, tcpPrefix):
+		sock, err := listenLoopback(strings.TrimPrefix(l.Address, tcpPrefix))
+		if err != nil {
+			return nil, err
+		}
+		return []net.Listener{sock}, nil
+
+	case l.Address == systemdPrefix || strings.HasPrefix(l.Address, systemdPrefix+":"):
+		return activatedListeners(strings.TrimPrefix(strings.TrimPrefix(l.Address, systemdPrefix), ":"))
+
+	}
+
+	// a leading @ selects the abstract namespace, which has no
+	// permissions; Validate rejects a mode, owner or group for these.
+	if !l.IsFilesystem() || (l.Mode == 0 && l.Owner == "" && l.Group == "") {
+		sock, err := net.Listen("unix", l.Address)
+		if err != nil {
+			return nil, err
+		}
+		return []net.Listener{sock}, nil
+	}
+
+	// create the socket accessible only to its owner, so that no peer
+	// can connect before the ownership and mode are applied.  The umask
+	// is process-wide, but listeners are opened before the server runs.
+	mask := syscall.Umask(0177)
+	sock, err := net.Listen("unix", l.Address)
+	syscall.Umask(mask)
+	if err != nil {
+		return nil, err
+	}
+
+	mode := l.Mode
+	if mode == 0 {
+		// the mode it would have been created with.
+		mode = os.FileMode(0777 &^ mask)
+	}
+
+	if err := setOwnership(l, mode); err != nil {
+		sock.Close()
+		return nil, err
+	}
+
+	return []net.Listener{sock}, nil
+}
+
+// listenLoopback listens on addr, which must be a loopback address.
+func listenLoopback(addr string) (net.Listener, error) {
+	taddr, err := net.ResolveTCPAddr("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if !taddr.IP.IsLoopback() {
+		return nil, fmt.Errorf("%v: not a loopback address", addr)
+	}
+
+	return net.ListenTCP("tcp", taddr)
+}
+
+// setOwnership applies the owner and group of a filesystem socket,
+// and then mode.
+func setOwnership(l Listener, mode os.FileMode) error {
+	uid, gid := -1, -1
+
+	if l.Owner != "" {
+		id, err := lookupID(l.Owner, func(name string) (string, error) {
+			u, err := user.Lookup(name)
+			if err != nil {
+				return "", err
+			}
+			return u.Uid, nil
+		})
+		if err != nil {
+			return fmt.Errorf("%v: owner: %v", l.Address, err)
+		}
+		uid = id
+	}
+
+	if l.Group != "" {
+		id, err := lookupID(l.Group, func(name string) (string, error) {
+			g, err := user.LookupGroup(name)
+			if err != nil {
+				return "", err
+			}
+			return g.Gid, nil
+		})
+		if err != nil {
+			return fmt.Errorf("%v: group: %v", l.Address, err)
+		}
+		gid = id
+	}
+
+	if uid >= 0 || gid >= 0 {
+		if err := os.Chown(l.Address, uid, gid); err != nil {
+			return err
+		}
+	}
+
+	return os.Chmod(l.Address, mode)
+}
+
+// lookupID returns the numeric value of a user or group given by name or id.
+func lookupID(name string, lookup func(string) (string, error)) (int, error) {
+	if id, err := strconv.Atoi(name); err == nil {
+		return id, nil
+	}
+
+	id, err := lookup(name)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.Atoi(id)
+}
+
+var errNoActivation = errors.New("systemd: no sockets passed")
+
+var activation struct {
+	once  sync.Once
+	names []string
+	files []*os.File
+	err   error
+}
+
+// activatedListeners returns the sockets passed by systemd with the given
+// name, or all of them if name is empty.  The environment is read once;
+// each socket can only be used by one listener.
+func activatedListeners(name string) ([]net.Listener, error) {
+	activation.once.Do(func() {
+		activation.names, activation.files, activation.err = activationFiles()
+	})
+
+	if activation.err != nil {
+		return nil, activation.err
+	}
+
+	var socks []net.Listener
+
+	for idx, file := range activation.files {
+		if file == nil || (name != "" && activation.names[idx] != name) {
+			continue
+		}
+
+		sock, err := net.FileListener(file)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %v", file.Name(), err)
+		}
+
+		file.Close()
+		activation.files[idx] = nil
+
+		socks = append(socks, sock)
+	}
+
+	if len(socks) == 0 {
+		if name == "" {
+			return nil, errNoActivation
+		}
+		return nil, fmt.Errorf("systemd: no sockets named %q passed", name)
+	}
+
+	return socks, nil
+}
+
+// activationFiles returns the descriptors passed by systemd socket
+// activation (sd_listen_fds(3)) and their names.  The variables are unset
+// so that they aren't inherited by child processes.
+func activationFiles() ([]string, []*os.File, error) {
+	defer os.Unsetenv("LISTEN_PID")
+	defer os.Unsetenv("LISTEN_FDS")
+	defer os.Unsetenv("LISTEN_FDNAMES")
+
+	pid, err := strconv.Atoi(os.Getenv("LISTEN_PID"))
+	if err != nil || pid != os.Getpid() {
+		return nil, nil, errNoActivation
+	}
+
+	count, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || count <= 0 {
+		return nil, nil, errNoActivation
+	}
+
+	fdnames := strings.Split(os.Getenv("LISTEN_FDNAMES"), ":")
+
+	var names []string
+	var files []*os.File
+
+	for idx := 0; idx < count; idx++ {
+		fd := listenFdsStart + idx
+
+		syscall.CloseOnExec(fd)
+
+		// systemd's default name.
+		name := "unknown"
+		if idx < len(fdnames) && fdnames[idx] != "" {
+			name = fdnames[idx]
+		}
+
+		names = append(names, name)
+		files = append(files, os.NewFile(uintptr(fd), fmt.Sprintf("systemd:%v", name)))
+	}
+
+	return names, files, nil
+}
@@ -0,0 +1,128 @@
+package rpc
+
+import (
+	"time"
+
+	"github.com/boz/circumspect/propset"
+)
+
+// NewPropSet converts a propset.PropSet into its wire representation.
+// Strings, ints, and maps of strings are written to the fields
+// understood by older clients; other properties to Values.
+func NewPropSet(pset propset.PropSet) *PropSet {
+	m := &PropSet{
+		Strings: make(map[string]string),
+		Ints:    make(map[string]int64),
+		Maps:    make(map[string]*StringMap),
+		Values:  make(map[string]*Value),
+	}
+
+	for name, prop := range pset {
+		switch prop := prop.(type) {
+		case propset.String:
+			m.Strings[name] = string(prop)
+		case propset.Int:
+			m.Ints[name] = int64(prop)
+		case propset.Map:
+			if values, ok := stringMap(prop); ok {
+				m.Maps[name] = &StringMap{Values: values}
+				continue
+			}
+			m.Values[name] = NewValue(prop)
+		default:
+			m.Values[name] = NewValue(prop)
+		}
+	}
+
+	return m
+}
+
+// stringMap returns the values of p if they are all strings.
+func stringMap(p propset.Map) (map[string]string, bool) {
+	values := make(map[string]string, len(p))
+	for k, v := range p {
+		s, ok := v.(propset.String)
+		if !ok {
+			return nil, false
+		}
+		values[k] = string(s)
+	}
+	return values, true
+}
+
+// NewValue converts a property into its wire representation.
+func NewValue(prop propset.Property) *Value {
+	switch prop := prop.(type) {
+	case propset.String:
+		return &Value{Type: ValueType_STRING, StringValue: string(prop)}
+	case propset.Int:
+		return &Value{Type: ValueType_INT, IntValue: int64(prop)}
+	case propset.Bool:
+		return &Value{Type: ValueType_BOOL, BoolValue: bool(prop)}
+	case propset.Time:
+		return &Value{Type: ValueType_TIME, TimeValue: prop.Time().UnixNano()}
+	case propset.List:
+		v := &Value{Type: ValueType_LIST}
+		for _, item := range prop {
+			v.ListValue = append(v.ListValue, NewValue(item))
+		}
+		return v
+	case propset.Map:
+		v := &Value{Type: ValueType_MAP, MapValue: make(map[string]*Value, len(prop))}
+		for k, item := range prop {
+			v.MapValue[k] = NewValue(item)
+		}
+		return v
+	default:
+		return &Value{Type: ValueType_STRING, StringValue: prop.String()}
+	}
+}
+
+// PropSet converts the wire representation back into a propset.PropSet.
+func (m *PropSet) PropSet() propset.PropSet {
+	pset := propset.New()
+
+	for name, value := range m.GetStrings() {
+		pset.AddString(name, value)
+	}
+
+	for name, value := range m.GetInts() {
+		pset.AddInt(name, int(value))
+	}
+
+	for name, value := range m.GetMaps() {
+		pset.AddMap(name, value.GetValues())
+	}
+
+	for name, value := range m.GetValues() {
+		pset.Add(name, value.Property())
+	}
+
+	return pset
+}
+
+// Property converts the wire representation back into a property.
+func (m *Value) Property() propset.Property {
+	switch m.GetType() {
+	case ValueType_INT:
+		return propset.Int(m.GetIntValue())
+	case ValueType_BOOL:
+		return propset.Bool(m.GetBoolValue())
+	case ValueType_TIME:
+		return propset.Time(time.Unix(0, m.GetTimeValue()).UTC())
+	case ValueType_LIST:
+		list := make(propset.List, 0, len(m.GetListValue()))
+		for _, item := range m.GetListValue() {
+			list = append(list, item.Property())
+		}
+		return list
+	case ValueType_MAP:
+		p := make(propset.Map, len(m.GetMapValue()))
+		for k, item := range m.GetMapValue() {
+			p[k] = item.Property()
+		}
+		return p
+	default:
+		return propset.String(m.GetStringValue())
+	}
+}
@@ -10,6 +10,19 @@ It is generated from these files:
 It has these top-level messages:
 	Request
 	Response
+	PropSet
+	StringMap
+	Value
+	JWTRequest
+	JWTResponse
+	JWKSRequest
+	JWKSResponse
+	X509Request
+	X509Response
+	X509BundleRequest
+	X509BundleResponse
+	WatchRequest
+	WatchResponse
 */
 package rpc
 
@@ -33,6 +46,39 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type ValueType int32
+
+const (
+	ValueType_STRING ValueType = 0
+	ValueType_INT    ValueType = 1
+	ValueType_BOOL   ValueType = 2
+	ValueType_TIME   ValueType = 3
+	ValueType_LIST   ValueType = 4
+	ValueType_MAP    ValueType = 5
+)
+
+var ValueType_name = map[int32]string{
+	0: "STRING",
+	1: "INT",
+	2: "BOOL",
+	3: "TIME",
+	4: "LIST",
+	5: "MAP",
+}
+var ValueType_value = map[string]int32{
+	"STRING": 0,
+	"INT":    1,
+	"BOOL":   2,
+	"TIME":   3,
+	"LIST":   4,
+	"MAP":    5,
+}
+
+func (x ValueType) String() string {
+	return proto.EnumName(ValueType_name, int32(x))
+}
+func (ValueType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
 type Request struct {
 }
 
@@ -42,6 +88,7 @@ func (*Request) ProtoMessage()               {}
 func (*Request) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
 
 type Response struct {
+	Props *PropSet `protobuf:"bytes,1,opt,name=props" json:"props,omitempty"`
 }
 
 func (m *Response) Reset()                    { *m = Response{} }
@@ -49,9 +96,374 @@ func (m *Response) String() string            { return proto.CompactTextString(m
 func (*Response) ProtoMessage()               {}
 func (*Response) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
 
+func (m *Response) GetProps() *PropSet {
+	if m != nil {
+		return m.Props
+	}
+	return nil
+}
+
+type PropSet struct {
+	Strings map[string]string     `protobuf:"bytes,1,rep,name=strings" json:"strings,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Ints    map[string]int64      `protobuf:"bytes,2,rep,name=ints" json:"ints,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	Maps    map[string]*StringMap `protobuf:"bytes,3,rep,name=maps" json:"maps,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Values  map[string]*Value     `protobuf:"bytes,4,rep,name=values" json:"values,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *PropSet) Reset()                    { *m = PropSet{} }
+func (m *PropSet) String() string            { return proto.CompactTextString(m) }
+func (*PropSet) ProtoMessage()               {}
+func (*PropSet) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *PropSet) GetStrings() map[string]string {
+	if m != nil {
+		return m.Strings
+	}
+	return nil
+}
+
+func (m *PropSet) GetInts() map[string]int64 {
+	if m != nil {
+		return m.Ints
+	}
+	return nil
+}
+
+func (m *PropSet) GetMaps() map[string]*StringMap {
+	if m != nil {
+		return m.Maps
+	}
+	return nil
+}
+
+func (m *PropSet) GetValues() map[string]*Value {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+type StringMap struct {
+	Values map[string]string `protobuf:"bytes,1,rep,name=values" json:"values,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *StringMap) Reset()                    { *m = StringMap{} }
+func (m *StringMap) String() string            { return proto.CompactTextString(m) }
+func (*StringMap) ProtoMessage()               {}
+func (*StringMap) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *StringMap) GetValues() map[string]string {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+type Value struct {
+	Type        ValueType         `protobuf:"varint,1,opt,name=type,enum=rpc.ValueType" json:"type,omitempty"`
+	StringValue string            `protobuf:"bytes,2,opt,name=string_value,json=stringValue" json:"string_value,omitempty"`
+	IntValue    int64             `protobuf:"varint,3,opt,name=int_value,json=intValue" json:"int_value,omitempty"`
+	BoolValue   bool              `protobuf:"varint,4,opt,name=bool_value,json=boolValue" json:"bool_value,omitempty"`
+	TimeValue   int64             `protobuf:"varint,5,opt,name=time_value,json=timeValue" json:"time_value,omitempty"`
+	ListValue   []*Value          `protobuf:"bytes,6,rep,name=list_value,json=listValue" json:"list_value,omitempty"`
+	MapValue    map[string]*Value `protobuf:"bytes,7,rep,name=map_value,json=mapValue" json:"map_value,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *Value) Reset()                    { *m = Value{} }
+func (m *Value) String() string            { return proto.CompactTextString(m) }
+func (*Value) ProtoMessage()               {}
+func (*Value) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *Value) GetType() ValueType {
+	if m != nil {
+		return m.Type
+	}
+	return ValueType_STRING
+}
+
+func (m *Value) GetStringValue() string {
+	if m != nil {
+		return m.StringValue
+	}
+	return ""
+}
+
+func (m *Value) GetIntValue() int64 {
+	if m != nil {
+		return m.IntValue
+	}
+	return 0
+}
+
+func (m *Value) GetBoolValue() bool {
+	if m != nil {
+		return m.BoolValue
+	}
+	return false
+}
+
+func (m *Value) GetTimeValue() int64 {
+	if m != nil {
+		return m.TimeValue
+	}
+	return 0
+}
+
+func (m *Value) GetListValue() []*Value {
+	if m != nil {
+		return m.ListValue
+	}
+	return nil
+}
+
+func (m *Value) GetMapValue() map[string]*Value {
+	if m != nil {
+		return m.MapValue
+	}
+	return nil
+}
+
+type JWTRequest struct {
+	Audience string `protobuf:"bytes,1,opt,name=audience" json:"audience,omitempty"`
+	Ttl      int64  `protobuf:"varint,2,opt,name=ttl" json:"ttl,omitempty"`
+}
+
+func (m *JWTRequest) Reset()                    { *m = JWTRequest{} }
+func (m *JWTRequest) String() string            { return proto.CompactTextString(m) }
+func (*JWTRequest) ProtoMessage()               {}
+func (*JWTRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *JWTRequest) GetAudience() string {
+	if m != nil {
+		return m.Audience
+	}
+	return ""
+}
+
+func (m *JWTRequest) GetTtl() int64 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+type JWTResponse struct {
+	Token     string `protobuf:"bytes,1,opt,name=token" json:"token,omitempty"`
+	ExpiresAt int64  `protobuf:"varint,2,opt,name=expires_at,json=expiresAt" json:"expires_at,omitempty"`
+}
+
+func (m *JWTResponse) Reset()                    { *m = JWTResponse{} }
+func (m *JWTResponse) String() string            { return proto.CompactTextString(m) }
+func (*JWTResponse) ProtoMessage()               {}
+func (*JWTResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *JWTResponse) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *JWTResponse) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+type JWKSRequest struct {
+}
+
+func (m *JWKSRequest) Reset()                    { *m = JWKSRequest{} }
+func (m *JWKSRequest) String() string            { return proto.CompactTextString(m) }
+func (*JWKSRequest) ProtoMessage()               {}
+func (*JWKSRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+type JWKSResponse struct {
+	Jwks string `protobuf:"bytes,1,opt,name=jwks" json:"jwks,omitempty"`
+}
+
+func (m *JWKSResponse) Reset()                    { *m = JWKSResponse{} }
+func (m *JWKSResponse) String() string            { return proto.CompactTextString(m) }
+func (*JWKSResponse) ProtoMessage()               {}
+func (*JWKSResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *JWKSResponse) GetJwks() string {
+	if m != nil {
+		return m.Jwks
+	}
+	return ""
+}
+
+type X509Request struct {
+	Csr []byte `protobuf:"bytes,1,opt,name=csr" json:"csr,omitempty"`
+	Ttl int64  `protobuf:"varint,2,opt,name=ttl" json:"ttl,omitempty"`
+}
+
+func (m *X509Request) Reset()                    { *m = X509Request{} }
+func (m *X509Request) String() string            { return proto.CompactTextString(m) }
+func (*X509Request) ProtoMessage()               {}
+func (*X509Request) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *X509Request) GetCsr() []byte {
+	if m != nil {
+		return m.Csr
+	}
+	return nil
+}
+
+func (m *X509Request) GetTtl() int64 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+type X509Response struct {
+	Certificates [][]byte `protobuf:"bytes,1,rep,name=certificates" json:"certificates,omitempty"`
+	PrivateKey   []byte   `protobuf:"bytes,2,opt,name=private_key,json=privateKey" json:"private_key,omitempty"`
+	ExpiresAt    int64    `protobuf:"varint,3,opt,name=expires_at,json=expiresAt" json:"expires_at,omitempty"`
+}
+
+func (m *X509Response) Reset()                    { *m = X509Response{} }
+func (m *X509Response) String() string            { return proto.CompactTextString(m) }
+func (*X509Response) ProtoMessage()               {}
+func (*X509Response) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *X509Response) GetCertificates() [][]byte {
+	if m != nil {
+		return m.Certificates
+	}
+	return nil
+}
+
+func (m *X509Response) GetPrivateKey() []byte {
+	if m != nil {
+		return m.PrivateKey
+	}
+	return nil
+}
+
+func (m *X509Response) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+type X509BundleRequest struct {
+}
+
+func (m *X509BundleRequest) Reset()                    { *m = X509BundleRequest{} }
+func (m *X509BundleRequest) String() string            { return proto.CompactTextString(m) }
+func (*X509BundleRequest) ProtoMessage()               {}
+func (*X509BundleRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+type X509BundleResponse struct {
+	Certificates [][]byte `protobuf:"bytes,1,rep,name=certificates" json:"certificates,omitempty"`
+}
+
+func (m *X509BundleResponse) Reset()                    { *m = X509BundleResponse{} }
+func (m *X509BundleResponse) String() string            { return proto.CompactTextString(m) }
+func (*X509BundleResponse) ProtoMessage()               {}
+func (*X509BundleResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *X509BundleResponse) GetCertificates() [][]byte {
+	if m != nil {
+		return m.Certificates
+	}
+	return nil
+}
+
+type WatchRequest struct {
+	JwtAudience string `protobuf:"bytes,1,opt,name=jwt_audience,json=jwtAudience" json:"jwt_audience,omitempty"`
+	JwtTtl      int64  `protobuf:"varint,2,opt,name=jwt_ttl,json=jwtTtl" json:"jwt_ttl,omitempty"`
+	X509        bool   `protobuf:"varint,3,opt,name=x509" json:"x509,omitempty"`
+	X509Ttl     int64  `protobuf:"varint,4,opt,name=x509_ttl,json=x509Ttl" json:"x509_ttl,omitempty"`
+}
+
+func (m *WatchRequest) Reset()                    { *m = WatchRequest{} }
+func (m *WatchRequest) String() string            { return proto.CompactTextString(m) }
+func (*WatchRequest) ProtoMessage()               {}
+func (*WatchRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *WatchRequest) GetJwtAudience() string {
+	if m != nil {
+		return m.JwtAudience
+	}
+	return ""
+}
+
+func (m *WatchRequest) GetJwtTtl() int64 {
+	if m != nil {
+		return m.JwtTtl
+	}
+	return 0
+}
+
+func (m *WatchRequest) GetX509() bool {
+	if m != nil {
+		return m.X509
+	}
+	return false
+}
+
+func (m *WatchRequest) GetX509Ttl() int64 {
+	if m != nil {
+		return m.X509Ttl
+	}
+	return 0
+}
+
+type WatchResponse struct {
+	Props *PropSet      `protobuf:"bytes,1,opt,name=props" json:"props,omitempty"`
+	Jwt   *JWTResponse  `protobuf:"bytes,2,opt,name=jwt" json:"jwt,omitempty"`
+	X509  *X509Response `protobuf:"bytes,3,opt,name=x509" json:"x509,omitempty"`
+}
+
+func (m *WatchResponse) Reset()                    { *m = WatchResponse{} }
+func (m *WatchResponse) String() string            { return proto.CompactTextString(m) }
+func (*WatchResponse) ProtoMessage()               {}
+func (*WatchResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *WatchResponse) GetProps() *PropSet {
+	if m != nil {
+		return m.Props
+	}
+	return nil
+}
+
+func (m *WatchResponse) GetJwt() *JWTResponse {
+	if m != nil {
+		return m.Jwt
+	}
+	return nil
+}
+
+func (m *WatchResponse) GetX509() *X509Response {
+	if m != nil {
+		return m.X509
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Request)(nil), "rpc.Request")
 	proto.RegisterType((*Response)(nil), "rpc.Response")
+	proto.RegisterType((*PropSet)(nil), "rpc.PropSet")
+	proto.RegisterType((*StringMap)(nil), "rpc.StringMap")
+	proto.RegisterType((*Value)(nil), "rpc.Value")
+	proto.RegisterType((*JWTRequest)(nil), "rpc.JWTRequest")
+	proto.RegisterType((*JWTResponse)(nil), "rpc.JWTResponse")
+	proto.RegisterType((*JWKSRequest)(nil), "rpc.JWKSRequest")
+	proto.RegisterType((*JWKSResponse)(nil), "rpc.JWKSResponse")
+	proto.RegisterType((*X509Request)(nil), "rpc.X509Request")
+	proto.RegisterType((*X509Response)(nil), "rpc.X509Response")
+	proto.RegisterType((*X509BundleRequest)(nil), "rpc.X509BundleRequest")
+	proto.RegisterType((*X509BundleResponse)(nil), "rpc.X509BundleResponse")
+	proto.RegisterType((*WatchRequest)(nil), "rpc.WatchRequest")
+	proto.RegisterType((*WatchResponse)(nil), "rpc.WatchResponse")
+	proto.RegisterEnum("rpc.ValueType", ValueType_name, ValueType_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -66,6 +478,11 @@ const _ = grpc.SupportPackageIsVersion4
 
 type WorkloadClient interface {
 	Register(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Response, error)
+	FetchJWT(ctx context.Context, in *JWTRequest, opts ...grpc.CallOption) (*JWTResponse, error)
+	FetchJWKS(ctx context.Context, in *JWKSRequest, opts ...grpc.CallOption) (*JWKSResponse, error)
+	FetchX509(ctx context.Context, in *X509Request, opts ...grpc.CallOption) (*X509Response, error)
+	FetchX509Bundle(ctx context.Context, in *X509BundleRequest, opts ...grpc.CallOption) (*X509BundleResponse, error)
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Workload_WatchClient, error)
 }
 
 type workloadClient struct {
@@ -85,10 +502,83 @@ func (c *workloadClient) Register(ctx context.Context, in *Request, opts ...grpc
 	return out, nil
 }
 
+func (c *workloadClient) FetchJWT(ctx context.Context, in *JWTRequest, opts ...grpc.CallOption) (*JWTResponse, error) {
+	out := new(JWTResponse)
+	err := grpc.Invoke(ctx, "/rpc.Workload/FetchJWT", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workloadClient) FetchJWKS(ctx context.Context, in *JWKSRequest, opts ...grpc.CallOption) (*JWKSResponse, error) {
+	out := new(JWKSResponse)
+	err := grpc.Invoke(ctx, "/rpc.Workload/FetchJWKS", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workloadClient) FetchX509(ctx context.Context, in *X509Request, opts ...grpc.CallOption) (*X509Response, error) {
+	out := new(X509Response)
+	err := grpc.Invoke(ctx, "/rpc.Workload/FetchX509", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workloadClient) FetchX509Bundle(ctx context.Context, in *X509BundleRequest, opts ...grpc.CallOption) (*X509BundleResponse, error) {
+	out := new(X509BundleResponse)
+	err := grpc.Invoke(ctx, "/rpc.Workload/FetchX509Bundle", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workloadClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Workload_WatchClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Workload_serviceDesc.Streams[0], c.cc, "/rpc.Workload/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &workloadWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Workload_WatchClient interface {
+	Recv() (*WatchResponse, error)
+	grpc.ClientStream
+}
+
+type workloadWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *workloadWatchClient) Recv() (*WatchResponse, error) {
+	m := new(WatchResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Server API for Workload service
 
 type WorkloadServer interface {
 	Register(context.Context, *Request) (*Response, error)
+	FetchJWT(context.Context, *JWTRequest) (*JWTResponse, error)
+	FetchJWKS(context.Context, *JWKSRequest) (*JWKSResponse, error)
+	FetchX509(context.Context, *X509Request) (*X509Response, error)
+	FetchX509Bundle(context.Context, *X509BundleRequest) (*X509BundleResponse, error)
+	Watch(*WatchRequest, Workload_WatchServer) error
 }
 
 func RegisterWorkloadServer(s *grpc.Server, srv WorkloadServer) {
@@ -113,6 +603,99 @@ func _Workload_Register_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Workload_FetchJWT_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JWTRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkloadServer).FetchJWT(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Workload/FetchJWT",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkloadServer).FetchJWT(ctx, req.(*JWTRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Workload_FetchJWKS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JWKSRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkloadServer).FetchJWKS(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Workload/FetchJWKS",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkloadServer).FetchJWKS(ctx, req.(*JWKSRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Workload_FetchX509_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(X509Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkloadServer).FetchX509(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Workload/FetchX509",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkloadServer).FetchX509(ctx, req.(*X509Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Workload_FetchX509Bundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(X509BundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkloadServer).FetchX509Bundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Workload/FetchX509Bundle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkloadServer).FetchX509Bundle(ctx, req.(*X509BundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Workload_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WorkloadServer).Watch(m, &workloadWatchServer{stream})
+}
+
+type Workload_WatchServer interface {
+	Send(*WatchResponse) error
+	grpc.ServerStream
+}
+
+type workloadWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *workloadWatchServer) Send(m *WatchResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _Workload_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "rpc.Workload",
 	HandlerType: (*WorkloadServer)(nil),
@@ -121,20 +704,89 @@ var _Workload_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Register",
 			Handler:    _Workload_Register_Handler,
 		},
+		{
+			MethodName: "FetchJWT",
+			Handler:    _Workload_FetchJWT_Handler,
+		},
+		{
+			MethodName: "FetchJWKS",
+			Handler:    _Workload_FetchJWKS_Handler,
+		},
+		{
+			MethodName: "FetchX509",
+			Handler:    _Workload_FetchX509_Handler,
+		},
+		{
+			MethodName: "FetchX509Bundle",
+			Handler:    _Workload_FetchX509Bundle_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _Workload_Watch_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "rpc/rpc.proto",
 }
 
 func init() { proto.RegisterFile("rpc/rpc.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 109 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x2d, 0x2a, 0x48, 0xd6,
-	0x2f, 0x2a, 0x48, 0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x2e, 0x2a, 0x48, 0x56, 0xe2,
-	0xe4, 0x62, 0x0f, 0x4a, 0x2d, 0x2c, 0x4d, 0x2d, 0x2e, 0x51, 0xe2, 0xe2, 0xe2, 0x08, 0x4a, 0x2d,
-	0x2e, 0xc8, 0xcf, 0x2b, 0x4e, 0x35, 0x32, 0xe5, 0xe2, 0x08, 0xcf, 0x2f, 0xca, 0xce, 0xc9, 0x4f,
-	0x4c, 0x11, 0xd2, 0x04, 0x89, 0xa7, 0x67, 0x16, 0x97, 0xa4, 0x16, 0x09, 0xf1, 0xe8, 0x81, 0xf4,
-	0x43, 0x75, 0x48, 0xf1, 0x42, 0x79, 0x10, 0x4d, 0x4a, 0x0c, 0x49, 0x6c, 0x60, 0x93, 0x8d, 0x01,
-	0x01, 0x00, 0x00, 0xff, 0xff, 0xed, 0x1f, 0xfd, 0xad, 0x6a, 0x00, 0x00, 0x00,
+	// 861 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0x4d, 0x6f, 0xdb, 0x46,
+	0x10, 0xb5, 0x44, 0x4a, 0x22, 0x87, 0x54, 0x42, 0x6f, 0x83, 0x44, 0x61, 0x51, 0xd4, 0x59, 0xb4,
+	0x80, 0x93, 0x83, 0xe3, 0x2a, 0x30, 0x1a, 0xfb, 0x66, 0xa3, 0xae, 0xa0, 0x38, 0x4a, 0x02, 0x8a,
+	0xa8, 0x7a, 0x13, 0x18, 0x7a, 0x9b, 0x50, 0xa2, 0xc8, 0x2d, 0xb9, 0x8a, 0xa2, 0x1e, 0xfa, 0xfb,
+	0x7a, 0xe9, 0xad, 0x3f, 0xa8, 0xd8, 0x0f, 0x32, 0xab, 0x8f, 0x22, 0x41, 0x4f, 0x9e, 0x99, 0xf7,
+	0xde, 0xec, 0xcc, 0xec, 0x70, 0x2d, 0xe8, 0x16, 0x34, 0x7e, 0x5a, 0xd0, 0xf8, 0x84, 0x16, 0x39,
+	0xcb, 0x91, 0x51, 0xd0, 0x18, 0xdb, 0xd0, 0x09, 0xc8, 0xef, 0x4b, 0x52, 0x32, 0x7c, 0x02, 0x56,
+	0x40, 0x4a, 0x9a, 0x67, 0x25, 0x41, 0x18, 0x5a, 0xb4, 0xc8, 0x69, 0xd9, 0x6b, 0x1c, 0x35, 0x8e,
+	0x9d, 0xbe, 0x7b, 0xc2, 0x65, 0x6f, 0x8a, 0x9c, 0x8e, 0x09, 0x0b, 0x24, 0x84, 0xff, 0x36, 0xa0,
+	0xa3, 0x42, 0xe8, 0x19, 0x74, 0x4a, 0x56, 0x24, 0xd9, 0x3b, 0xae, 0x30, 0x8e, 0x9d, 0xfe, 0x43,
+	0x5d, 0x71, 0x32, 0x96, 0xd8, 0x75, 0xc6, 0x8a, 0x75, 0x50, 0x31, 0xd1, 0x13, 0x30, 0x93, 0x8c,
+	0x95, 0xbd, 0xa6, 0x50, 0xdc, 0xdf, 0x50, 0x0c, 0x33, 0xa6, 0xe8, 0x82, 0xc3, 0xb9, 0x8b, 0x88,
+	0x96, 0x3d, 0x63, 0x0f, 0x77, 0x14, 0xd1, 0x8a, 0xcb, 0x39, 0xe8, 0x14, 0xda, 0x1f, 0xa2, 0x74,
+	0x49, 0xca, 0x9e, 0x29, 0xd8, 0xbd, 0x0d, 0xf6, 0x2f, 0x02, 0x92, 0x7c, 0xc5, 0xf3, 0x2f, 0xc0,
+	0xd5, 0x4b, 0x44, 0x1e, 0x18, 0x73, 0xb2, 0x16, 0xcd, 0xdb, 0x01, 0x37, 0xd1, 0x3d, 0x68, 0x09,
+	0x6e, 0xaf, 0x29, 0x62, 0xd2, 0xb9, 0x68, 0x3e, 0x6f, 0xf8, 0x3f, 0x82, 0x5d, 0x17, 0xfb, 0x39,
+	0xa1, 0xa1, 0x0b, 0x07, 0x60, 0xd7, 0x95, 0xef, 0x11, 0x7e, 0xa7, 0x0b, 0x9d, 0xfe, 0x1d, 0xd1,
+	0x84, 0xac, 0x72, 0x14, 0x51, 0x3d, 0xd1, 0x35, 0x38, 0x5a, 0x53, 0x7b, 0x52, 0x1d, 0x6d, 0xa6,
+	0x02, 0x91, 0x4a, 0x48, 0xb4, 0x34, 0xf8, 0x0f, 0xb0, 0xeb, 0xf4, 0xa8, 0x5f, 0xcf, 0x50, 0xde,
+	0xa7, 0xbf, 0x79, 0xfc, 0xde, 0x29, 0x9e, 0x7f, 0xae, 0x8e, 0xff, 0x1c, 0x22, 0xfe, 0xa7, 0x09,
+	0x2d, 0xa1, 0x45, 0x18, 0x4c, 0xb6, 0xa6, 0x44, 0xc8, 0xee, 0xa8, 0xae, 0x05, 0x12, 0xae, 0x29,
+	0x09, 0x04, 0x86, 0x1e, 0x81, 0x2b, 0x77, 0x68, 0xaa, 0xa7, 0x73, 0x64, 0x4c, 0xa6, 0xf9, 0x1a,
+	0xec, 0x24, 0x63, 0x0a, 0x37, 0xc4, 0xe8, 0xad, 0x24, 0x63, 0x12, 0xfc, 0x06, 0xe0, 0x6d, 0x9e,
+	0xa7, 0x0a, 0x35, 0x8f, 0x1a, 0xc7, 0x56, 0x60, 0xf3, 0x48, 0x0d, 0xb3, 0x64, 0x41, 0x14, 0xdc,
+	0x12, 0x62, 0x9b, 0x47, 0x24, 0xfc, 0x18, 0x20, 0x4d, 0xca, 0x2a, 0x77, 0xfb, 0xc8, 0xd8, 0x1a,
+	0xa9, 0xcd, 0x51, 0x49, 0x3d, 0x03, 0x7b, 0x11, 0x51, 0xc5, 0xec, 0x68, 0xcb, 0x28, 0x60, 0xbe,
+	0xb8, 0xc2, 0x90, 0x63, 0xb4, 0x16, 0xca, 0xf5, 0x07, 0xd0, 0xdd, 0x80, 0xfe, 0xf7, 0x95, 0x5e,
+	0x00, 0xbc, 0x98, 0x84, 0xea, 0x03, 0x47, 0x3e, 0x58, 0xd1, 0xf2, 0x36, 0x21, 0x59, 0x4c, 0x54,
+	0xaa, 0xda, 0xe7, 0x27, 0x30, 0x96, 0xaa, 0x25, 0xe5, 0x26, 0xbe, 0x02, 0x47, 0x68, 0xd5, 0x8b,
+	0x70, 0x0f, 0x5a, 0x2c, 0x9f, 0x93, 0x4c, 0x29, 0xa5, 0xc3, 0x47, 0x45, 0x3e, 0xd2, 0xa4, 0x20,
+	0xe5, 0x34, 0x62, 0x4a, 0x6d, 0xab, 0xc8, 0x25, 0xc3, 0x5d, 0x9e, 0xe3, 0x66, 0x5c, 0xbd, 0x30,
+	0x18, 0x5c, 0xe9, 0xaa, 0x9c, 0x08, 0xcc, 0xd9, 0x6a, 0x5e, 0xaa, 0x94, 0xc2, 0xc6, 0x3f, 0x80,
+	0xf3, 0xeb, 0xd9, 0xe9, 0x79, 0x55, 0xb3, 0x07, 0x46, 0x5c, 0x16, 0x82, 0xe1, 0x06, 0xdc, 0xdc,
+	0x53, 0x69, 0x01, 0xae, 0x94, 0xd4, 0x8f, 0x97, 0x1b, 0x93, 0x82, 0x25, 0xbf, 0x25, 0x71, 0xc4,
+	0xd4, 0x06, 0xbb, 0xc1, 0x46, 0x0c, 0x7d, 0x0b, 0x0e, 0x2d, 0x92, 0x0f, 0x11, 0x23, 0x53, 0x3e,
+	0xd9, 0xa6, 0xc8, 0x0f, 0x2a, 0x74, 0x43, 0xd6, 0x5b, 0x9d, 0x19, 0xdb, 0x9d, 0x7d, 0x05, 0x87,
+	0xfc, 0xcc, 0xab, 0x65, 0x76, 0x9b, 0x92, 0xaa, 0xbf, 0xe7, 0x80, 0xf4, 0xe0, 0x97, 0x97, 0x83,
+	0xd7, 0xe0, 0x4e, 0x22, 0x16, 0xbf, 0xaf, 0xda, 0x7e, 0x04, 0xee, 0x6c, 0xc5, 0xa6, 0x5b, 0xd7,
+	0xe5, 0xcc, 0x56, 0xec, 0xb2, 0xba, 0xb1, 0x07, 0xd0, 0xe1, 0x94, 0x4f, 0xb3, 0x68, 0xcf, 0x56,
+	0x2c, 0x64, 0x29, 0x9f, 0xea, 0xc7, 0xb3, 0xd3, 0x73, 0x51, 0xb3, 0x15, 0x08, 0x1b, 0x3d, 0x04,
+	0x8b, 0xff, 0x15, 0x6c, 0x53, 0xb0, 0x3b, 0xdc, 0x0f, 0x59, 0x8a, 0xff, 0x84, 0xae, 0x3a, 0xfa,
+	0xcb, 0xdf, 0x7e, 0x84, 0xc1, 0x98, 0xad, 0x98, 0x5a, 0x3e, 0x4f, 0x30, 0xb4, 0x65, 0x09, 0x38,
+	0x88, 0xbe, 0xd7, 0xea, 0x70, 0xfa, 0x87, 0x82, 0xa4, 0xdf, 0x93, 0x2c, 0xed, 0xc9, 0x00, 0xec,
+	0xfa, 0xfb, 0x46, 0x00, 0xed, 0x71, 0x18, 0x0c, 0x5f, 0x0d, 0xbc, 0x03, 0xd4, 0x01, 0x63, 0xf8,
+	0x2a, 0xf4, 0x1a, 0xc8, 0x02, 0xf3, 0xea, 0xf5, 0xeb, 0x97, 0x5e, 0x93, 0x5b, 0xe1, 0x70, 0x74,
+	0xed, 0x19, 0xdc, 0x7a, 0x39, 0x1c, 0x87, 0x9e, 0xc9, 0x69, 0xa3, 0xcb, 0x37, 0x5e, 0xab, 0xff,
+	0x57, 0x13, 0xac, 0x49, 0x5e, 0xcc, 0xd3, 0x3c, 0xba, 0x45, 0x8f, 0xf9, 0x3f, 0xb3, 0x77, 0x49,
+	0xc9, 0x48, 0x81, 0x64, 0x07, 0x6a, 0xb4, 0x7e, 0x57, 0x79, 0xb2, 0x08, 0x7c, 0x80, 0x9e, 0x82,
+	0xf5, 0x33, 0x61, 0xf1, 0xfb, 0x17, 0x93, 0x10, 0xdd, 0xfd, 0xd4, 0x8a, 0x64, 0xef, 0xf4, 0x86,
+	0x0f, 0x50, 0x1f, 0x6c, 0x25, 0xb8, 0x19, 0xa3, 0x8a, 0x50, 0x6f, 0xb9, 0x7f, 0xa8, 0x45, 0x76,
+	0x34, 0x7c, 0x00, 0x4a, 0xa3, 0xad, 0xb9, 0xbf, 0x3b, 0x1d, 0x7c, 0x80, 0x7e, 0x82, 0xbb, 0xb5,
+	0x46, 0xee, 0x14, 0xba, 0x5f, 0xf3, 0x36, 0x36, 0xcf, 0x7f, 0xb0, 0x13, 0xd7, 0x4e, 0x6e, 0x89,
+	0xfb, 0x45, 0xf2, 0x0c, 0x7d, 0xcd, 0x7c, 0xa4, 0x87, 0x2a, 0xc5, 0x69, 0xe3, 0x6d, 0x5b, 0xfc,
+	0x42, 0x78, 0xf6, 0xef, 0x00, 0x27, 0x9d, 0x10, 0x99, 0x32, 0x08, 0x00, 0x00,
 }
@@ -1,60 +1,356 @@
 package rpc
 
 import (
+	"encoding/json"
 	"errors"
 	"net"
+	"strings"
+	"time"
 
 	context "golang.org/x/net/context"
 
+	"github.com/boz/circumspect/identity/jwt"
+	"github.com/boz/circumspect/identity/x509ca"
+	"github.com/boz/circumspect/policy"
+	"github.com/boz/circumspect/propset"
 	"github.com/boz/circumspect/resolver/uds"
 	udsgrpc "github.com/boz/circumspect/resolver/uds/grpc"
 	"github.com/sirupsen/logrus"
 	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var pkglog = logrus.StandardLogger().WithField("package", "rpc")
 
-func RunServer(ctx context.Context, path string, fn func(context.Context, uds.Props)) error {
+// Handler resolves the properties of a connected peer.
+type Handler func(context.Context, uds.Props) (propset.PropSet, error)
+
+// ServerOption configures optional server features.
+type ServerOption func(*server)
+
+// WatchHandler resolves the properties of a connected peer each time they change.
+// The returned channel must be closed when the context is cancelled.
+type WatchHandler func(context.Context, uds.Props) <-chan propset.PropSet
+
+// WithWatchHandler enables the Watch method.
+func WithWatchHandler(fn WatchHandler) ServerOption {
+	return func(s *server) {
+		s.watchFn = fn
+	}
+}
+
+// ChangeHandler is called when the properties of a watching peer change.
+type ChangeHandler func(ChangeEvent)
+
+// WithChangeHandler calls fn, in addition to logging, each time the
+// properties of a watching peer change.
+func WithChangeHandler(fn ChangeHandler) ServerOption {
+	return func(s *server) {
+		s.changeFn = fn
+	}
+}
+
+// WithCheck rejects peers whose properties fail fn with codes.Unavailable.
+// It is applied to the result of the Handler and to each update of a Watch.
+func WithCheck(fn func(propset.PropSet) error) ServerOption {
+	return func(s *server) {
+		s.checkFn = fn
+	}
+}
+
+// WithJWTIssuer enables the FetchJWT and FetchJWKS methods.
+func WithJWTIssuer(issuer jwt.Issuer) ServerOption {
+	return func(s *server) {
+		s.jwtIssuer = issuer
+	}
+}
+
+// WithX509CA enables the FetchX509 and FetchX509Bundle methods.
+func WithX509CA(ca x509ca.CA) ServerOption {
+	return func(s *server) {
+		s.x509CA = ca
+	}
+}
+
+// WithPolicy rejects peers whose properties are denied by the given policy.
+func WithPolicy(p *policy.Policy) ServerOption {
+	return func(s *server) {
+		s.policy = p
+	}
+}
+
+// RunServer accepts clients on the given listeners until ctx is cancelled
+// or one of them fails.  Each listener is served by its own gRPC server so
+// that its label and policy apply to the peers connected to it.
+func RunServer(ctx context.Context, listeners []Listener, fn Handler, opts ...ServerOption) error {
 	log := pkglog.WithField("component", "server")
 
-	sock, err := net.Listen("unix", path)
-	if err != nil {
-		log.WithError(err).Errorf("error listening on %v", path)
-		return err
+	if len(listeners) == 0 {
+		return errors.New("no listeners")
 	}
 
-	donech := make(chan struct{})
-	defer func() { <-donech }()
+	base := server{log: log, fn: fn}
+	for _, opt := range opts {
+		opt(&base)
+	}
 
-	go func() {
-		defer close(donech)
-		<-ctx.Done()
-		sock.Close()
-	}()
+	// open every socket before serving any so that a bad listener
+	// doesn't leave the others running.
+	socks := make([][]net.Listener, len(listeners))
 
-	s := grpc.NewServer(grpc.Creds(udsgrpc.NewCredentials()))
+	closeAll := func() {
+		for _, group := range socks {
+			for _, sock := range group {
+				sock.Close()
+			}
+		}
+	}
 
-	RegisterWorkloadServer(s, &server{log, fn})
+	for idx, l := range listeners {
+		err := l.Validate()
+		if err == nil {
+			socks[idx], err = listen(l)
+		}
+		if err != nil {
+			log.WithError(err).Errorf("error listening on %v", l)
+			closeAll()
+			return err
+		}
+		log.Debugf("listening on %v", l)
+	}
 
-	return s.Serve(sock)
+	var servers []*grpc.Server
+
+	errch := make(chan error, len(listeners))
+	count := 0
+
+	for idx, l := range listeners {
+		srv := base
+		srv.listener = l
+		if l.Label != "" {
+			srv.log = log.WithField("listener", l.Label)
+		}
+
+		var credOpts []udsgrpc.Option
+		if strings.HasPrefix(l.Address, tcpPrefix) {
+			credOpts = append(credOpts, udsgrpc.AllowLoopbackTCP())
+		}
+
+		s := grpc.NewServer(
+			grpc.Creds(udsgrpc.NewCredentials(credOpts...)),
+			grpc.UnaryInterceptor(udsgrpc.UnaryServerInterceptor()),
+			grpc.StreamInterceptor(udsgrpc.StreamServerInterceptor()))
+
+		RegisterWorkloadServer(s, &srv)
+
+		servers = append(servers, s)
+
+		for _, sock := range socks[idx] {
+			count++
+			go func(sock net.Listener) {
+				errch <- s.Serve(sock)
+			}(sock)
+		}
+	}
+
+	var err error
+
+	select {
+	case <-ctx.Done():
+	case err = <-errch:
+		count--
+		log.WithError(err).Error("error serving")
+	}
+
+	for _, s := range servers {
+		s.Stop()
+	}
+
+	for ; count > 0; count-- {
+		<-errch
+	}
+
+	return err
 }
 
 type server struct {
-	log logrus.FieldLogger
-	fn  func(context.Context, uds.Props)
+	log       logrus.FieldLogger
+	listener  Listener
+	fn        Handler
+	watchFn   WatchHandler
+	changeFn  ChangeHandler
+	checkFn   func(propset.PropSet) error
+	jwtIssuer jwt.Issuer
+	x509CA    x509ca.CA
+	policy    *policy.Policy
 }
 
 func (s *server) Register(ctx context.Context, req *Request) (*Response, error) {
+	pset, err := s.resolve(ctx, "register")
+	if err != nil {
+		return &Response{}, err
+	}
+	return &Response{Props: NewPropSet(pset)}, nil
+}
+
+func (s *server) FetchJWT(ctx context.Context, req *JWTRequest) (*JWTResponse, error) {
+	if s.jwtIssuer == nil {
+		return nil, status.Error(codes.Unimplemented, "jwt issuer not configured")
+	}
+
+	pset, err := s.resolve(ctx, "jwt")
+	if err != nil {
+		return nil, err
+	}
+
+	return s.issueJWT(pset, req.GetAudience(), req.GetTtl())
+}
+
+func (s *server) FetchJWKS(ctx context.Context, req *JWKSRequest) (*JWKSResponse, error) {
+	if s.jwtIssuer == nil {
+		return nil, status.Error(codes.Unimplemented, "jwt issuer not configured")
+	}
+
+	buf, err := json.Marshal(s.jwtIssuer.JWKS())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "error encoding jwks")
+	}
+
+	return &JWKSResponse{Jwks: string(buf)}, nil
+}
+
+func (s *server) FetchX509(ctx context.Context, req *X509Request) (*X509Response, error) {
+	if s.x509CA == nil {
+		return nil, status.Error(codes.Unimplemented, "x509 ca not configured")
+	}
+
+	pset, err := s.resolve(ctx, "x509")
+	if err != nil {
+		return nil, err
+	}
+
+	return s.issueX509(pset, req.GetCsr(), req.GetTtl())
+}
+
+func (s *server) FetchX509Bundle(ctx context.Context, req *X509BundleRequest) (*X509BundleResponse, error) {
+	if s.x509CA == nil {
+		return nil, status.Error(codes.Unimplemented, "x509 ca not configured")
+	}
+
+	resp := &X509BundleResponse{}
+	for _, cert := range s.x509CA.Bundle() {
+		resp.Certificates = append(resp.Certificates, cert.Raw)
+	}
+
+	return resp, nil
+}
+
+func (s *server) issueJWT(pset propset.PropSet, audience string, ttl int64) (*JWTResponse, error) {
+	token, exp, err := s.jwtIssuer.Issue(pset, audience, time.Duration(ttl)*time.Second)
+	switch err {
+	case nil:
+	case jwt.ErrInvalidAudience, jwt.ErrInvalidTTL:
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	default:
+		s.log.WithError(err).Error("error issuing jwt")
+		return nil, status.Error(codes.Internal, "error issuing jwt")
+	}
+
+	return &JWTResponse{Token: token, ExpiresAt: exp.Unix()}, nil
+}
+
+func (s *server) issueX509(pset propset.PropSet, csr []byte, ttl int64) (*X509Response, error) {
+	issued, err := s.x509CA.Issue(pset, csr, time.Duration(ttl)*time.Second)
+	switch err {
+	case nil:
+	case x509ca.ErrInvalidCSR, x509ca.ErrInvalidTTL:
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	default:
+		s.log.WithError(err).Error("error issuing certificate")
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	return &X509Response{
+		Certificates: issued.Certificates,
+		PrivateKey:   issued.PrivateKey,
+		ExpiresAt:    issued.ExpiresAt.Unix(),
+	}, nil
+}
+
+// peerProps returns the properties of the peer connected to ctx.
+func (s *server) peerProps(ctx context.Context, method string) (uds.Props, error) {
 	props, ok := udsgrpc.PropsFromContext(ctx)
 
 	if !ok {
 		s.log.Warnf("no properties for peer")
-		return &Response{}, errors.New("no peer properties")
+		return nil, status.Error(codes.Unauthenticated, "no peer properties")
+	}
+
+	s.log.Debugf("%v request from [pid: %v uid: %v gid: %v]", method, props.Pid(), props.Uid(), props.Gid())
+
+	return props, nil
+}
+
+// resolve looks up the properties of the peer connected to ctx.
+func (s *server) resolve(ctx context.Context, method string) (propset.PropSet, error) {
+	props, err := s.peerProps(ctx, method)
+	if err != nil {
+		return nil, err
+	}
+
+	pset, err := s.fn(ctx, props)
+	switch {
+	case uds.IsProcessChanged(err):
+		s.log.WithError(err).Warn("rejecting peer")
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	case err != nil:
+		s.log.WithError(err).Warnf("error resolving peer %v", props.Pid())
+		return nil, status.Error(codes.Unavailable, err.Error())
 	}
 
-	s.log.Debugf("register request from [pid: %v uid: %v gid: %v]", props.Pid(), props.Uid(), props.Gid())
+	pset = s.withListener(pset)
 
-	s.fn(ctx, props)
+	if err := s.authorize(pset); err != nil {
+		return nil, err
+	}
+
+	return pset, nil
+}
+
+// withListener returns pset with the label of the listener the peer is
+// connected to, if any.
+func (s *server) withListener(pset propset.PropSet) propset.PropSet {
+	if s.listener.Label == "" {
+		return pset
+	}
+	return propset.New().
+		Merge(pset).
+		AddString("server-listener", s.listener.Label)
+}
+
+// authorize applies the configured check, then evaluates the configured
+// policy and the policy of the listener, if any, against pset.
+func (s *server) authorize(pset propset.PropSet) error {
+	if s.checkFn != nil {
+		if err := s.checkFn(pset); err != nil {
+			s.log.WithError(err).Warn("peer rejected by check")
+			return status.Error(codes.Unavailable, err.Error())
+		}
+	}
+
+	for _, p := range []*policy.Policy{s.policy, s.listener.Policy} {
+		if p == nil {
+			continue
+		}
+
+		decision := p.Evaluate(pset)
+
+		if !decision.Allow {
+			s.log.WithField("policy-rule", decision.Rule).Infof("peer denied: %v", decision)
+			return status.Error(codes.PermissionDenied, decision.String())
+		}
+	}
 
-	return &Response{}, nil
+	return nil
 }
@@ -0,0 +1,159 @@
+package rpc
+
+import (
+	"time"
+
+	"github.com/boz/circumspect/propset"
+	"github.com/boz/circumspect/resolver/uds"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// minRenewal is the shortest time between credential renewals.  A
+// stream whose credentials expire sooner than twice this (e.g. because
+// they are capped at the CA's expiry) is ended instead of being renewed
+// in a tight loop.
+const minRenewal = 10 * time.Second
+
+func (s *server) Watch(req *WatchRequest, stream Workload_WatchServer) error {
+	if s.watchFn == nil {
+		return status.Error(codes.Unimplemented, "watch not configured")
+	}
+	if req.GetJwtAudience() != "" && s.jwtIssuer == nil {
+		return status.Error(codes.Unimplemented, "jwt issuer not configured")
+	}
+	if req.GetX509() && s.x509CA == nil {
+		return status.Error(codes.Unimplemented, "x509 ca not configured")
+	}
+
+	ctx := stream.Context()
+
+	props, err := s.peerProps(ctx, "watch")
+	if err != nil {
+		return err
+	}
+
+	log := s.log.WithField("component", "watch").WithField("pid", props.Pid())
+
+	updates := s.watchFn(ctx, props)
+
+	var pset propset.PropSet
+
+	var timer *time.Timer
+	var renewch <-chan time.Time
+
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case p, ok := <-updates:
+			if !ok {
+				return nil
+			}
+			p = s.withListener(p)
+			if pset != nil {
+				s.propsChanged(log, props, pset, p)
+			}
+			pset = p
+
+		case <-renewch:
+			log.Debug("renewing credentials")
+		}
+
+		if err := s.authorize(pset); err != nil {
+			return err
+		}
+
+		resp, expires, err := s.watchResponse(pset, req)
+		if err != nil {
+			return err
+		}
+
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+
+		if timer != nil {
+			timer.Stop()
+			timer, renewch = nil, nil
+		}
+
+		// renew credentials halfway through their lifetime.
+		if !expires.IsZero() {
+			lifetime := time.Until(expires)
+			if lifetime < 2*minRenewal {
+				log.WithField("expires", expires).Warn("credentials expire too soon to renew")
+				return status.Errorf(codes.FailedPrecondition, "credentials expire in %v; too soon to renew", lifetime/time.Second*time.Second)
+			}
+			timer = time.NewTimer(lifetime / 2)
+			renewch = timer.C
+		}
+	}
+}
+
+// ChangeEvent records a change to the properties of a watching peer.
+type ChangeEvent struct {
+	Time     time.Time       `json:"time"`
+	Pid      int             `json:"pid"`
+	Listener string          `json:"listener,omitempty"`
+	Changes  propset.Changes `json:"changes"`
+}
+
+// propsChanged logs the differences between prev and pset and passes
+// them to the change handler, if any.
+func (s *server) propsChanged(log logrus.FieldLogger, props uds.Props, prev, pset propset.PropSet) {
+	changes := propset.Diff(prev, pset)
+	if len(changes) == 0 {
+		log.Debug("properties unchanged")
+		return
+	}
+
+	log.WithField("changes", changes.String()).Info("properties changed")
+
+	if s.changeFn != nil {
+		s.changeFn(ChangeEvent{
+			Time:     time.Now().UTC(),
+			Pid:      props.Pid(),
+			Listener: s.listener.Label,
+			Changes:  changes,
+		})
+	}
+}
+
+// watchResponse builds a response for pset, including any requested credentials.
+// The earliest credential expiry time is returned.
+func (s *server) watchResponse(pset propset.PropSet, req *WatchRequest) (*WatchResponse, time.Time, error) {
+	resp := &WatchResponse{Props: NewPropSet(pset)}
+
+	var expires time.Time
+
+	if req.GetJwtAudience() != "" {
+		token, err := s.issueJWT(pset, req.GetJwtAudience(), req.GetJwtTtl())
+		if err != nil {
+			return nil, expires, err
+		}
+		resp.Jwt = token
+		expires = time.Unix(token.GetExpiresAt(), 0)
+	}
+
+	if req.GetX509() {
+		cert, err := s.issueX509(pset, nil, req.GetX509Ttl())
+		if err != nil {
+			return nil, expires, err
+		}
+		resp.X509 = cert
+		if exp := time.Unix(cert.GetExpiresAt(), 0); expires.IsZero() || exp.Before(expires) {
+			expires = exp
+		}
+	}
+
+	return resp, expires, nil
+}